@@ -0,0 +1,206 @@
+package scoro
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without sending request while circuit breaker
+// is open.
+var ErrCircuitOpen = errors.New("scoro: circuit breaker is open")
+
+// BreakerState is state of circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed state lets all requests through.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen state rejects all requests with ErrCircuitOpen.
+	BreakerOpen
+
+	// BreakerHalfOpen state lets single probe request through, its result
+	// closes or opens the breaker again.
+	BreakerHalfOpen
+)
+
+func (t BreakerState) String() string {
+	switch t {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// CircuitBreaker stops sending requests when Scoro API is degraded. Breaker
+// opens after threshold of consecutive failures, rejects requests with
+// ErrCircuitOpen during cooldown and then lets single probe request through.
+// Successful probe closes the breaker, failed one opens it for another
+// cooldown.
+//
+// Transport failures and 5xx responses are counted as failures, other API
+// errors (like validation errors) are not.
+//
+// Example:
+//
+//		breaker := scoro.NewCircuitBreaker(5, 30*time.Second).
+//			OnStateChange(func(from, to scoro.BreakerState) {
+//				log.Printf("scoro circuit breaker: %v -> %v", from, to)
+//			})
+//		client := scoro.NewClient(credentials).SetCircuitBreaker(breaker)
+type CircuitBreaker struct {
+	threshold     int
+	cooldown      time.Duration
+	onStateChange func(from BreakerState, to BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	// generation is incremented on every state change. Results of requests
+	// allowed in previous generations are ignored, so only the probe decides
+	// state of half-open breaker.
+	generation uint64
+}
+
+// NewCircuitBreaker creates breaker opening after threshold consecutive
+// failures for cooldown duration.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// OnStateChange sets callback called on every state change. Callback is
+// called synchronously by the request which caused the change.
+func (t *CircuitBreaker) OnStateChange(callback func(from BreakerState, to BreakerState)) *CircuitBreaker {
+	t.onStateChange = callback
+	return t
+}
+
+// State returns current state of the breaker.
+func (t *CircuitBreaker) State() BreakerState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state == BreakerOpen && time.Since(t.openedAt) >= t.cooldown {
+		return BreakerHalfOpen
+	}
+
+	return t.state
+}
+
+// Private
+
+// allow checks whether request can be sent. It returns generation of the
+// breaker, which must be passed to record with result of the request.
+func (t *CircuitBreaker) allow() (uint64, error) {
+	t.mu.Lock()
+	from := t.state
+
+	switch t.state {
+	case BreakerOpen:
+		if time.Since(t.openedAt) < t.cooldown {
+			t.mu.Unlock()
+			return 0, ErrCircuitOpen
+		}
+
+		t.state = BreakerHalfOpen
+		t.generation++
+		t.probing = true
+	case BreakerHalfOpen:
+		if t.probing {
+			t.mu.Unlock()
+			return 0, ErrCircuitOpen
+		}
+
+		t.probing = true
+	}
+
+	to := t.state
+	generation := t.generation
+	t.mu.Unlock()
+
+	t.notify(from, to)
+	return generation, nil
+}
+
+// record registers result of request allowed by the breaker in specified
+// generation. Results of requests allowed before the last state change are
+// ignored, e.g. slow request sent while the breaker was closed doesn't close
+// half-open breaker.
+func (t *CircuitBreaker) record(generation uint64, err error) {
+	failed := isBreakerFailure(err)
+
+	t.mu.Lock()
+	if generation != t.generation {
+		t.mu.Unlock()
+		return
+	}
+
+	from := t.state
+
+	switch {
+	case t.state == BreakerHalfOpen && errors.Is(err, context.Canceled):
+		// Canceled probe says nothing about API state, next request probes.
+	case t.state == BreakerHalfOpen && failed:
+		t.open()
+	case t.state == BreakerHalfOpen:
+		t.state = BreakerClosed
+		t.generation++
+		t.failures = 0
+	case failed:
+		t.failures++
+		if t.failures >= t.threshold {
+			t.open()
+		}
+	default:
+		t.failures = 0
+	}
+
+	t.probing = false
+	to := t.state
+	t.mu.Unlock()
+
+	t.notify(from, to)
+}
+
+func (t *CircuitBreaker) open() {
+	t.state = BreakerOpen
+	t.generation++
+	t.openedAt = time.Now()
+	t.failures = 0
+}
+
+func (t *CircuitBreaker) notify(from BreakerState, to BreakerState) {
+	if from != to && t.onStateChange != nil {
+		t.onStateChange(from, to)
+	}
+}
+
+func isBreakerFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	return errors.Is(err, ErrServer)
+}
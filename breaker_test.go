@@ -0,0 +1,154 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+
+	type step struct {
+		fail  bool
+		wait  time.Duration
+		err   error
+		state scoro.BreakerState
+	}
+
+	tests := []struct {
+		name        string
+		steps       []step
+		transitions []string
+	}{
+		{
+			name: "success resets failures",
+			steps: []step{
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerClosed},
+				{state: scoro.BreakerClosed},
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerClosed},
+			},
+		},
+		{
+			name: "opens after threshold",
+			steps: []step{
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerClosed},
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerOpen},
+				{err: scoro.ErrCircuitOpen, state: scoro.BreakerOpen},
+			},
+			transitions: []string{"closed->open"},
+		},
+		{
+			name: "successful probe closes",
+			steps: []step{
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerClosed},
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerOpen},
+				{wait: cooldown, state: scoro.BreakerClosed},
+				{state: scoro.BreakerClosed},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->closed"},
+		},
+		{
+			name: "failed probe opens again",
+			steps: []step{
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerClosed},
+				{fail: true, err: scoro.ErrServer, state: scoro.BreakerOpen},
+				{wait: cooldown, fail: true, err: scoro.ErrServer, state: scoro.BreakerOpen},
+				{err: scoro.ErrCircuitOpen, state: scoro.BreakerOpen},
+			},
+			transitions: []string{"closed->open", "open->half-open", "half-open->open"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var failing atomic.Bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if failing.Load() {
+					unavailable(w, r)
+					return
+				}
+				fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+			}))
+			defer srv.Close()
+
+			var mu sync.Mutex
+			var transitions []string
+			breaker := scoro.NewCircuitBreaker(2, cooldown).OnStateChange(func(from, to scoro.BreakerState) {
+				mu.Lock()
+				defer mu.Unlock()
+				transitions = append(transitions, fmt.Sprintf("%v->%v", from, to))
+			})
+			products := scoro.NewClient(testCredentials).SetBaseURL(srv.URL + "/api").SetCircuitBreaker(breaker).Products()
+
+			for i, step := range test.steps {
+				time.Sleep(step.wait)
+				failing.Store(step.fail)
+
+				_, err := products.List(context.Background(), nil, 1, 10)
+				if step.err == nil && err != nil || !errors.Is(err, step.err) {
+					t.Fatalf("step %d: got error %v, want %v", i, err, step.err)
+				}
+
+				if state := breaker.State(); state != step.state {
+					t.Fatalf("step %d: got state %v, want %v", i, state, step.state)
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if fmt.Sprint(transitions) != fmt.Sprint(test.transitions) {
+				t.Errorf("got transitions %v, want %v", transitions, test.transitions)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerIgnoresStaleResults(t *testing.T) {
+	slow := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/view/") {
+			<-slow
+			fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":{"product_id":1}}`)
+			return
+		}
+		unavailable(w, r)
+	}))
+	defer srv.Close()
+
+	breaker := scoro.NewCircuitBreaker(1, time.Hour)
+	products := scoro.NewClient(testCredentials).SetBaseURL(srv.URL + "/api").SetCircuitBreaker(breaker).Products()
+
+	// Request sent while breaker is closed finishes after it has opened.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		products.View(context.Background(), "1")
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := products.List(context.Background(), nil, 1, 10); !errors.Is(err, scoro.ErrServer) {
+		t.Fatal(err)
+	}
+
+	close(slow)
+	<-done
+
+	if state := breaker.State(); state != scoro.BreakerOpen {
+		t.Errorf("got state %v, want open", state)
+	}
+}
+
+func unavailable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusServiceUnavailable)
+	fmt.Fprint(w, `{"status":"ERROR","statusCode":"503","messages":{"error":["Service unavailable"]}}`)
+}
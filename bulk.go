@@ -0,0 +1,158 @@
+package scoro
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// DefaultDeleteConcurrency is number of concurrent delete calls of
+// DeleteMany and DeleteWhere.
+const DefaultDeleteConcurrency = 4
+
+// ErrNotConfirmed is returned by DeleteMany and DeleteWhere when confirm
+// function rejects deletion.
+var ErrNotConfirmed = errors.New("scoro: deletion isn't confirmed")
+
+// ConfirmFunc receives records which are going to be deleted and reports
+// whether they can be deleted.
+type ConfirmFunc[T any] func(ctx context.Context, records []T) bool
+
+// DeleteResult holds result of deleting single record.
+type DeleteResult struct {
+	ID  int
+	Err error
+}
+
+// DeleteOptions configures DeleteMany and DeleteWhere. Zero value deletes
+// records without confirmation, DefaultDeleteConcurrency at once.
+type DeleteOptions[T any] struct {
+	// Concurrency is maximum number of concurrent delete calls.
+	Concurrency int
+
+	// Confirm is asked before anything is deleted, nothing is deleted if it
+	// returns false, so it can be used to preview affected records:
+	//
+	//		preview := func(ctx context.Context, products []scoro.Product) bool {
+	//			for _, product := range products {
+	//				fmt.Println(*product.Id, product.Code)
+	//			}
+	//			return false
+	//		}
+	//		_, err := scoro.DeleteWhere(ctx, client.Products(), filter, scoro.DeleteOptions[scoro.Product]{Confirm: preview})
+	//
+	// Client in ModeDryRun also lists affected records, but logs simulated
+	// delete calls instead.
+	Confirm ConfirmFunc[T]
+}
+
+// DeleteMany deletes records of service with specified ids and returns
+// result of each of them in the same order. Failure of single record doesn't
+// stop deletion of others, returned error reports failures of confirmation
+// only.
+//
+// Records are loaded to be passed to confirm function, if it is set. Records
+// that can't be loaded are reported in results and aren't deleted.
+func DeleteMany[T any](ctx context.Context, service EntityService[T], ids []int, options DeleteOptions[T]) ([]DeleteResult, error) {
+	results := make([]DeleteResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+
+	if options.Confirm != nil {
+		records := make([]T, len(ids))
+		options.forEach(ctx, results, func(ctx context.Context, i int) error {
+			record, err := service.View(ctx, strconv.Itoa(ids[i]))
+			if err == nil {
+				records[i] = *record
+			}
+
+			return err
+		})
+
+		var confirmed []T
+		for i, result := range results {
+			if result.Err == nil {
+				confirmed = append(confirmed, records[i])
+			}
+		}
+
+		if !options.Confirm(ctx, confirmed) {
+			return results, ErrNotConfirmed
+		}
+	}
+
+	options.forEach(ctx, results, func(ctx context.Context, i int) error {
+		return service.Delete(ctx, ids[i])
+	})
+
+	return results, nil
+}
+
+// DeleteWhere deletes all records of service matching filter. Matching
+// records are loaded before any of them is deleted, so paging isn't affected
+// by deletion. Results are reported like by DeleteMany.
+//
+//		results, err := scoro.DeleteWhere(ctx, client.Products(), filter, scoro.DeleteOptions[scoro.Product]{})
+func DeleteWhere[T any, PT Entity[T]](ctx context.Context, service EntityService[T], filter Filter, options DeleteOptions[T]) ([]DeleteResult, error) {
+	records, err := NewPager(service.List, filter).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(records))
+	for i := range records {
+		ids[i] = PT(&records[i]).GetID()
+	}
+
+	if options.Confirm != nil && !options.Confirm(ctx, records) {
+		results := make([]DeleteResult, len(ids))
+		for i, id := range ids {
+			results[i].ID = id
+		}
+
+		return results, ErrNotConfirmed
+	}
+
+	options.Confirm = nil
+	return DeleteMany(ctx, service, ids, options)
+}
+
+// Private
+
+// forEach runs fn for indexes of results which have no error yet, up to
+// concurrency limit at once, and stores returned errors.
+func (t DeleteOptions[T]) forEach(ctx context.Context, results []DeleteResult, fn func(ctx context.Context, i int) error) {
+	concurrency := t.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultDeleteConcurrency
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			results[i].Err = fn(ctx, i)
+		}(i)
+	}
+
+	wg.Wait()
+}
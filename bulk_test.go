@@ -0,0 +1,122 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestDeleteWhere(t *testing.T) {
+	tests := []struct {
+		name    string
+		options scoro.DeleteOptions[scoro.Product]
+		deleted []int
+		err     error
+	}{
+		{
+			name:    "deletes matching records",
+			deleted: []int{1, 3, 4},
+		},
+		{
+			name:    "deletes concurrently",
+			options: scoro.DeleteOptions[scoro.Product]{Concurrency: 2},
+			deleted: []int{1, 3, 4},
+		},
+		{
+			name: "confirmed deletion",
+			options: scoro.DeleteOptions[scoro.Product]{Confirm: func(ctx context.Context, products []scoro.Product) bool {
+				return len(products) == 3
+			}},
+			deleted: []int{1, 3, 4},
+		},
+		{
+			name: "rejected deletion",
+			options: scoro.DeleteOptions[scoro.Product]{Confirm: func(ctx context.Context, products []scoro.Product) bool {
+				return false
+			}},
+			deleted: []int{},
+			err:     scoro.ErrNotConfirmed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			products := srv.Client().Products()
+			for _, code := range []string{"a", "b", "a", "a"} {
+				if _, err := srv.Put("products", scoro.Product{Code: code}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			results, err := scoro.DeleteWhere(context.Background(), products, scoro.ProductFilter{Code: "a"}, test.options)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			if len(results) != 3 {
+				t.Fatalf("got %d results, want 3", len(results))
+			}
+
+			for _, result := range results {
+				if test.err == nil && result.Err != nil {
+					t.Errorf("record %d: %v", result.ID, result.Err)
+				}
+			}
+
+			deleted := []int{}
+			for _, record := range srv.Records("products") {
+				if record["is_deleted"] == "1" {
+					id, _ := strconv.Atoi(fmt.Sprint(record["product_id"]))
+					deleted = append(deleted, id)
+				}
+			}
+
+			if fmt.Sprint(deleted) != fmt.Sprint(test.deleted) {
+				t.Errorf("got deleted %v, want %v", deleted, test.deleted)
+			}
+		})
+	}
+}
+
+func TestDeleteMany(t *testing.T) {
+	srv := newTestServer(t)
+	products := srv.Client().Products()
+	for _, code := range []string{"a", "b"} {
+		if _, err := srv.Put("products", scoro.Product{Code: code}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var confirmed []string
+	options := scoro.DeleteOptions[scoro.Product]{Confirm: func(ctx context.Context, products []scoro.Product) bool {
+		for _, product := range products {
+			confirmed = append(confirmed, product.Code)
+		}
+		return true
+	}}
+
+	results, err := scoro.DeleteMany(context.Background(), products, []int{2, 99, 1}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(confirmed) != "[b a]" {
+		t.Errorf("got confirmed %v, want records which can be loaded", confirmed)
+	}
+
+	want := []struct {
+		id  int
+		err error
+	}{{2, nil}, {99, scoro.ErrNotFound}, {1, nil}}
+
+	for i, result := range results {
+		if result.ID != want[i].id || !errors.Is(result.Err, want[i].err) {
+			t.Errorf("result %d: got %v %v, want %v %v", i, result.ID, result.Err, want[i].id, want[i].err)
+		}
+	}
+}
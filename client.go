@@ -0,0 +1,216 @@
+package scoro
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Client holds configuration shared by all services of a single Scoro
+// account: credentials, HTTP client, endpoint template, API version, timeouts,
+// user agent and language. Each client owns its own HTTP client, so several
+// clients (for instance, for different tenants) can be used within one process
+// without affecting each other.
+//
+// Client is configured by Set* methods, which should be called before the
+// client is used by services. Configured client is safe for concurrent use.
+//
+// Example:
+//
+//		client := scoro.NewClient(credentials).SetTimeout(10 * time.Second)
+//		product, err := client.Products().View(ctx, "1")
+type Client struct {
+	credentials CredentialsProvider
+	httpClient  *http.Client
+	baseURL     string
+	version     APIVersion
+	userAgent   string
+	lang        string
+	retry       RetryPolicy
+	middleware  []Middleware
+	logger      *slog.Logger
+	logBodies   bool
+	metrics     Metrics
+	limiter     *RateLimiter
+	breaker     *CircuitBreaker
+	mode        Mode
+}
+
+// NewClient creates client configured with specified credentials and default
+// settings.
+func NewClient(credentials Credentials) *Client {
+	return NewClientWithProvider(StaticCredentials(credentials))
+}
+
+// NewClientWithProvider creates client resolving credentials of each call by
+// provider, e.g. to serve several tenants or to rotate API keys.
+func NewClientWithProvider(provider CredentialsProvider) *Client {
+	return &Client{
+		credentials: provider,
+		httpClient:  &http.Client{Timeout: DefaultTimeout},
+		baseURL:     DefaultBaseURL,
+		version:     APIv1,
+		userAgent:   DefaultUserAgent,
+		lang:        DefaultLang,
+	}
+}
+
+// SetCredentialsProvider replaces provider of credentials.
+func (t *Client) SetCredentialsProvider(provider CredentialsProvider) *Client {
+	t.credentials = provider
+	return t
+}
+
+// SetHTTPClient replaces HTTP client used to send requests.
+func (t *Client) SetHTTPClient(httpClient *http.Client) *Client {
+	t.httpClient = httpClient
+	return t
+}
+
+// SetTransport sets transport of the HTTP client used to send requests.
+func (t *Client) SetTransport(transport http.RoundTripper) *Client {
+	httpClient := *t.httpClient
+	httpClient.Transport = transport
+	t.httpClient = &httpClient
+	return t
+}
+
+// SetTimeout sets overall timeout of a single HTTP request. Use context
+// deadlines for request-scoped timeouts.
+func (t *Client) SetTimeout(timeout time.Duration) *Client {
+	httpClient := *t.httpClient
+	httpClient.Timeout = timeout
+	t.httpClient = &httpClient
+	return t
+}
+
+// SetBaseURL overrides endpoint template of the API, DefaultBaseURL by default.
+// Template can contain {subdomain} placeholder, which is replaced with subdomain
+// of the credentials. API version, module and action are appended to it, so
+// requests are sent to {baseURL}/{version}/{module}/{action}.
+//
+// It can be used to point client to a local stand-in server or a proxy:
+//
+//		client.SetBaseURL("http://127.0.0.1:8080/api")
+func (t *Client) SetBaseURL(baseURL string) *Client {
+	t.baseURL = baseURL
+	return t
+}
+
+// SetAPIVersion selects version of Scoro API endpoints and request/response
+// envelope, APIv1 by default.
+func (t *Client) SetAPIVersion(version APIVersion) *Client {
+	t.version = version
+	return t
+}
+
+// SetRetryPolicy sets policy of automatic retries of failed requests. New
+// clients don't retry requests.
+func (t *Client) SetRetryPolicy(policy RetryPolicy) *Client {
+	t.retry = policy
+	return t
+}
+
+// Use appends middleware to the chain wrapped around each API call. The first
+// registered middleware is the outermost one. Middleware is called once per
+// call, retries happen inside the chain.
+func (t *Client) Use(middleware ...Middleware) *Client {
+	t.middleware = append(t.middleware, middleware...)
+	return t
+}
+
+// SetLogger enables logging of API calls: module, action, id, latency and
+// statuses of each call. Failed calls are logged at error level, successful
+// ones at debug level. Credentials are never logged.
+func (t *Client) SetLogger(logger *slog.Logger) *Client {
+	t.logger = logger
+	return t
+}
+
+// SetLogBodies enables logging of request and response bodies at debug level.
+func (t *Client) SetLogBodies(enabled bool) *Client {
+	t.logBodies = enabled
+	return t
+}
+
+// SetMetrics sets hook receiving stats of each API call.
+func (t *Client) SetMetrics(metrics Metrics) *Client {
+	t.metrics = metrics
+	return t
+}
+
+// SetRateLimiter sets limiter of requests, shared by all services of the
+// client. Calls block until limiter allows request or context is done.
+func (t *Client) SetRateLimiter(limiter *RateLimiter) *Client {
+	t.limiter = limiter
+	return t
+}
+
+// SetCircuitBreaker sets circuit breaker of requests, shared by all services
+// of the client.
+func (t *Client) SetCircuitBreaker(breaker *CircuitBreaker) *Client {
+	t.breaker = breaker
+	return t
+}
+
+// SetMode sets mode of modify and delete actions, ModeReadWrite by default.
+// ModeReadOnly and ModeDryRun guarantee that client doesn't change data, e.g.
+// in staging runs of sync jobs:
+//
+//		client := scoro.NewClient(credentials).SetMode(scoro.ModeDryRun)
+func (t *Client) SetMode(mode Mode) *Client {
+	t.mode = mode
+	return t
+}
+
+// SetUserAgent sets value of User-Agent header sent with each request.
+func (t *Client) SetUserAgent(userAgent string) *Client {
+	t.userAgent = userAgent
+	return t
+}
+
+// SetLang sets language of requests, "eng" by default.
+func (t *Client) SetLang(lang string) *Client {
+	t.lang = lang
+	return t
+}
+
+// Products returns products service bound to the client.
+func (t *Client) Products() ProductsAPI {
+	return NewService[Product](t, "products")
+}
+
+// Quotes returns quotes service bound to the client.
+func (t *Client) Quotes() QuotesAPI {
+	return NewService[Quote](t, "quotes")
+}
+
+// Orders returns orders service bound to the client.
+func (t *Client) Orders() OrdersAPI {
+	return NewService[Order](t, "orders")
+}
+
+// Invoices returns invoices service bound to the client.
+func (t *Client) Invoices() InvoicesAPI {
+	return NewService[Invoice](t, "invoices")
+}
+
+// PrepaymentInvoices returns prepayments service bound to the client.
+func (t *Client) PrepaymentInvoices() InvoicesAPI {
+	return NewService[Invoice](t, "invoices/prepayments")
+}
+
+// Contacts returns contacts service bound to the client.
+func (t *Client) Contacts() ContactsAPI {
+	return NewService[Contact](t, "contacts")
+}
+
+// Receipts returns receipts service bound to the client.
+func (t *Client) Receipts() ReceiptsAPI {
+	return NewService[Receipt](t, "receipts")
+}
+
+// Relations returns relations service bound to the client.
+func (t *Client) Relations() RelationsAPI {
+	return NewService[Relation](t, "relations")
+}
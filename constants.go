@@ -1,3 +1,17 @@
 package scoro
 
+import "time"
+
 const DefaultLang = "eng"
+
+// SubdomainPlaceholder is replaced with subdomain of the credentials in base URL.
+const SubdomainPlaceholder = "{subdomain}"
+
+// DefaultBaseURL is endpoint template used by new clients.
+const DefaultBaseURL = "https://" + SubdomainPlaceholder + ".scoro.com/api"
+
+// DefaultTimeout is timeout of a single HTTP request used by new clients.
+const DefaultTimeout = 30 * time.Second
+
+// DefaultUserAgent is value of User-Agent header used by new clients.
+const DefaultUserAgent = "go-scoro"
@@ -1,9 +1,5 @@
 package scoro
 
-import (
-	"errors"
-)
-
 // Contact struct represents contacts data type of Scoro API.
 // https://api.scoro.com/api/#contactsApiDocs
 type Contact struct {
@@ -28,10 +24,40 @@ type Contact struct {
 	Tags           []string          `json:"tags,omitempty"`
 	ReferenceNo    string            `json:"reference_no,omitempty"`
 	CustomFields   map[string]string `json:"custom_fields,omitempty"`
+	DeletedDate    Time              `json:"deleted_date,omitempty"`
 	IsDeleted      Bool              `json:"is_deleted"`
 }
 type ContactList []Contact
 
+// GetID returns ID of the contact, zero for new contacts.
+func (t *Contact) GetID() int {
+	if t.ContactID == nil {
+		return 0
+	}
+
+	return *t.ContactID
+}
+
+// SetID sets ID of the contact.
+func (t *Contact) SetID(id int) {
+	t.ContactID = &id
+}
+
+// GetModifiedDate returns last modification time of the contact.
+func (t *Contact) GetModifiedDate() Time {
+	return t.ModifiedDate
+}
+
+// GetDeletedDate returns deletion time of the contact.
+func (t *Contact) GetDeletedDate() Time {
+	return t.DeletedDate
+}
+
+// Deleted reports whether the contact is deleted.
+func (t *Contact) Deleted() bool {
+	return t.IsDeleted.Value
+}
+
 type Address struct {
 	Country      string `json:"country,omitempty"`
 	County       string `json:"county,omitempty"`
@@ -52,82 +78,9 @@ type MeansOfContact struct {
 
 // ContactsAPI provides type safe wrappers for View/List/Modify/Delete actions
 // of contacts API
-type ContactsAPI struct {
-	credentials Credentials
-}
+type ContactsAPI = Service[Contact]
 
+// Contacts is shortcut for NewClient(credentials).Contacts().
 func Contacts(credentials Credentials) ContactsAPI {
-	return ContactsAPI{credentials}
-}
-
-func (t ContactsAPI) View(id string) (*Contact, error) {
-	resp, err := t.Request().SetResponse(contactResponse{}).View(id)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*contactResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Contact, nil
-}
-
-func (t ContactsAPI) List(filter interface{}, page int, count int) (*ContactList, error) {
-	resp, err := t.Request().SetResponse(contactListResponse{}).List(filter, page, count)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*contactListResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Contacts, nil
-}
-
-func (t ContactsAPI) Modify(product Contact) (*Contact, error) {
-	resp, err := t.Request().SetResponse(contactResponse{}).Modify(product)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*contactResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Contact, nil
-}
-
-func (t ContactsAPI) Delete(id int) error {
-	_, err := t.Request().SetResponse(contactResponse{}).Delete(id, nil)
-
-	return err
-}
-
-func (t ContactsAPI) Request() Request {
-	return NewRequest(t.credentials, "contacts")
-}
-
-// Private
-
-type contactResponse struct {
-	ResponseHeader `json:",inline"`
-	Contact        Contact `json:"data,omitempty"`
-}
-
-type contactListResponse struct {
-	ResponseHeader `json:",inline"`
-	Contacts       ContactList `json:"data,omitempty"`
-}
-
-func (t contactResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
-}
-
-func (t contactListResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+	return NewClient(credentials).Contacts()
 }
@@ -0,0 +1,147 @@
+package scoro
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// APIVersion identifies version of Scoro API endpoints used by client.
+type APIVersion string
+
+const (
+	// APIv1 is version 1 of Scoro API, used by default.
+	APIv1 APIVersion = "v1"
+
+	// APIv2 is version 2 of Scoro API.
+	APIv2 APIVersion = "v2"
+)
+
+// ResponseHeaderV2 represents base part of API v2 response that is common for
+// all responses. It differs from v1 ResponseHeader by numeric status code.
+//
+// Response types don't depend on API version: v2 header is converted into
+// ResponseHeader before response is unmarshalled into registered response type.
+type ResponseHeaderV2 struct {
+	Status     string `json:"status"`
+	StatusCode int    `json:"statusCode"`
+	Messages   *struct {
+		Error []string `json:"error"`
+	} `json:"messages,omitempty"`
+}
+
+// ResponseHeader converts v2 header into ResponseHeader.
+func (t ResponseHeaderV2) ResponseHeader() ResponseHeader {
+	header := ResponseHeader{Status: t.Status, Messages: t.Messages}
+	if t.StatusCode != 0 {
+		header.StatusCode = strconv.Itoa(t.StatusCode)
+	}
+
+	return header
+}
+
+// Private
+
+// envelope encodes request body and decodes response of particular API version.
+// Request body is encoded without credentials, they are added by authorize
+// right before request is sent, so encoded body can be safely exposed.
+type envelope interface {
+	encodeRequest(body requestBody) ([]byte, error)
+	authorize(credentials Credentials, data []byte) ([]byte, error)
+	decodeResponse(data []byte, response ResponseType) error
+	encodeResponse(header ResponseHeader, data json.RawMessage) ([]byte, error)
+}
+
+func envelopeFor(version APIVersion) envelope {
+	if version == APIv2 {
+		return envelopeV2{}
+	}
+
+	return envelopeV1{}
+}
+
+type envelopeV1 struct{}
+
+func (t envelopeV1) encodeRequest(body requestBody) ([]byte, error) {
+	return json.Marshal(body)
+}
+
+func (t envelopeV1) authorize(credentials Credentials, data []byte) ([]byte, error) {
+	return mergeCredentials(credentials, data)
+}
+
+func (t envelopeV1) decodeResponse(data []byte, response ResponseType) error {
+	return json.Unmarshal(data, response)
+}
+
+func (t envelopeV1) encodeResponse(header ResponseHeader, data json.RawMessage) ([]byte, error) {
+	return json.Marshal(responseBodyV1{ResponseHeader: header, Data: data})
+}
+
+// requestBodyV2 is request envelope of API v2. It has the same layout as v1
+// request body.
+type requestBodyV2 requestBody
+
+type responseBodyV2 struct {
+	ResponseHeaderV2 `json:",inline"`
+	Data             json.RawMessage `json:"data,omitempty"`
+}
+
+type responseBodyV1 struct {
+	ResponseHeader `json:",inline"`
+	Data           json.RawMessage `json:"data,omitempty"`
+}
+
+type envelopeV2 struct{}
+
+func (t envelopeV2) encodeRequest(body requestBody) ([]byte, error) {
+	return json.Marshal(requestBodyV2(body))
+}
+
+func (t envelopeV2) authorize(credentials Credentials, data []byte) ([]byte, error) {
+	return mergeCredentials(credentials, data)
+}
+
+func (t envelopeV2) decodeResponse(data []byte, response ResponseType) error {
+	var body responseBodyV2
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	// Response types are declared in terms of v1 header, so response is
+	// rebuilt in v1 layout before it is unmarshalled into response type.
+	normalized, err := json.Marshal(responseBodyV1{
+		ResponseHeader: body.ResponseHeaderV2.ResponseHeader(),
+		Data:           body.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(normalized, response)
+}
+
+func (t envelopeV2) encodeResponse(header ResponseHeader, data json.RawMessage) ([]byte, error) {
+	headerV2 := ResponseHeaderV2{Status: header.Status, Messages: header.Messages}
+	headerV2.StatusCode, _ = strconv.Atoi(header.StatusCode)
+
+	return json.Marshal(responseBodyV2{ResponseHeaderV2: headerV2, Data: data})
+}
+
+// mergeCredentials adds fields of credentials to encoded request body.
+func mergeCredentials(credentials Credentials, data []byte) ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	credentialsData, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(credentialsData, &fields); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
@@ -0,0 +1,103 @@
+package scoro_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+type productResponse struct {
+	scoro.ResponseHeader `json:",inline"`
+	Data                 scoro.Product `json:"data"`
+}
+
+func TestAPIVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		version  scoro.APIVersion
+		response string
+		path     string
+		err      bool
+	}{
+		{
+			name:     "v1",
+			baseURL:  "/api",
+			response: `{"status":"OK","statusCode":"200","data":{"product_id":1,"code":"A"}}`,
+			path:     "/api/v1/products/view/1",
+		},
+		{
+			name:     "v2",
+			baseURL:  "/api",
+			version:  scoro.APIv2,
+			response: `{"status":"OK","statusCode":200,"data":{"product_id":1,"code":"A"}}`,
+			path:     "/api/v2/products/view/1",
+		},
+		{
+			name:     "v2 error",
+			baseURL:  "/api",
+			version:  scoro.APIv2,
+			response: `{"status":"ERROR","statusCode":404,"messages":{"error":["Not found"]}}`,
+			path:     "/api/v2/products/view/1",
+			err:      true,
+		},
+		{
+			name:     "subdomain placeholder with trailing slash",
+			baseURL:  "/" + scoro.SubdomainPlaceholder + "/api/",
+			response: `{"status":"OK","statusCode":"200","data":{"product_id":1,"code":"A"}}`,
+			path:     "/test/api/v1/products/view/1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var path string
+			var body map[string]interface{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				data, _ := io.ReadAll(r.Body)
+				json.Unmarshal(data, &body)
+
+				io.WriteString(w, test.response)
+			}))
+			defer srv.Close()
+
+			credentials := scoro.Credentials{ApiKey: "key", CompanyID: "company", Subdomain: "test"}
+			client := scoro.NewClient(credentials).SetBaseURL(srv.URL + test.baseURL)
+			if test.version != "" {
+				client.SetAPIVersion(test.version)
+			}
+
+			resp, err := scoro.NewRequest(client, "products").SetResponse(productResponse{}).View(context.Background(), "1")
+
+			if path != test.path {
+				t.Errorf("got path %q, want %q", path, test.path)
+			}
+
+			if body["apiKey"] != "key" || body["company_account_id"] != "company" || body["lang"] != scoro.DefaultLang {
+				t.Errorf("got request body %v", body)
+			}
+
+			if test.err {
+				if err == nil {
+					t.Error("got no error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			product := resp.(*productResponse)
+			if product.StatusCode != "200" || product.Data.Code != "A" {
+				t.Errorf("got status code %q and product code %q", product.StatusCode, product.Data.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package scoro
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors returned by API calls. They are matched by errors.Is
+// against errors returned by services, for instance:
+//
+//		if _, err := client.Products().View(ctx, id); errors.Is(err, scoro.ErrNotFound) {
+//			// create product
+//		}
+var (
+	// ErrNotFound is matched by API errors about missing records.
+	ErrNotFound = errors.New("scoro: not found")
+
+	// ErrUnauthorized is matched by API errors caused by invalid or
+	// insufficient credentials.
+	ErrUnauthorized = errors.New("scoro: unauthorized")
+
+	// ErrRateLimited is matched by API errors caused by exceeded request limits.
+	ErrRateLimited = errors.New("scoro: rate limited")
+
+	// ErrValidation is matched by API errors about invalid request data.
+	ErrValidation = errors.New("scoro: validation failed")
+
+	// ErrServer is matched by API errors caused by failures on Scoro side.
+	ErrServer = errors.New("scoro: server error")
+
+	// ErrInvalidResponse is wrapped by DecodeError when response doesn't
+	// conform to the registered response type.
+	ErrInvalidResponse = errors.New("scoro: invalid response format")
+)
+
+// APIError is returned when Scoro API responds with non-200 HTTP status or
+// with status other than "OK" in the response header.
+//
+// Use errors.As to access details of the error:
+//
+//		var apiErr *scoro.APIError
+//		if errors.As(err, &apiErr) {
+//			fmt.Println(apiErr.StatusCode, apiErr.Messages)
+//		}
+type APIError struct {
+	// Module, Action and ID identify failed request, ID is empty for
+	// list and modify actions.
+	Module string
+	Action string
+	ID     string
+
+	// HTTPStatus holds HTTP status code of the response.
+	HTTPStatus int
+
+	// StatusCode holds statusCode value of the response header.
+	StatusCode string
+
+	// Messages holds error messages of the response header.
+	Messages []string
+
+	// RetryAfter holds delay requested by Retry-After header of the response.
+	RetryAfter time.Duration
+
+	// Attempts holds number of attempts made before the error was returned.
+	Attempts int
+}
+
+func (t *APIError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("scoro: ")
+	b.WriteString(t.Module + "/" + t.Action)
+	if t.ID != "" {
+		b.WriteString(" " + t.ID)
+	}
+
+	if code := t.Code(); code != 0 {
+		fmt.Fprintf(&b, ": %d %v", code, http.StatusText(code))
+	}
+
+	if len(t.Messages) > 0 {
+		b.WriteString(": " + strings.Join(t.Messages, "; "))
+	}
+
+	if t.Attempts > 1 {
+		fmt.Fprintf(&b, " (%d attempts)", t.Attempts)
+	}
+
+	return b.String()
+}
+
+// Code returns numeric status of the error. Scoro status code takes
+// precedence over HTTP status, since Scoro can report failures with 200 OK.
+func (t *APIError) Code() int {
+	if code, err := strconv.Atoi(t.StatusCode); err == nil && code != 0 {
+		return code
+	}
+
+	return t.HTTPStatus
+}
+
+// Is matches error against sentinel errors by status code.
+func (t *APIError) Is(target error) bool {
+	code := t.Code()
+
+	switch target {
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized || code == http.StatusForbidden
+	case ErrRateLimited:
+		return code == http.StatusTooManyRequests
+	case ErrValidation:
+		return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+	case ErrServer:
+		return code >= http.StatusInternalServerError
+	}
+
+	return false
+}
+
+// TransportError is returned when request can't be sent or response can't be
+// received, for instance, because of network failure or canceled context.
+type TransportError struct {
+	Module string
+	Action string
+	ID     string
+	Err    error
+
+	// Attempts holds number of attempts made before the error was returned.
+	Attempts int
+}
+
+func (t *TransportError) Error() string {
+	if t.Attempts > 1 {
+		return fmt.Sprintf("scoro: %v/%v: %v (%d attempts)", t.Module, t.Action, t.Err, t.Attempts)
+	}
+
+	return fmt.Sprintf("scoro: %v/%v: %v", t.Module, t.Action, t.Err)
+}
+
+func (t *TransportError) Unwrap() error {
+	return t.Err
+}
+
+// DecodeError is returned when response can't be decoded into the
+// expected response type.
+type DecodeError struct {
+	Module string
+	Action string
+	Err    error
+}
+
+func (t *DecodeError) Error() string {
+	return fmt.Sprintf("scoro: %v/%v: decode response: %v", t.Module, t.Action, t.Err)
+}
+
+func (t *DecodeError) Unwrap() error {
+	return t.Err
+}
+
+// Private
+
+func newAPIError(call *Call, resp *Response) *APIError {
+	err := &APIError{
+		Module:     call.Module,
+		Action:     call.Action,
+		ID:         call.ID,
+		HTTPStatus: resp.HTTPStatus,
+		StatusCode: resp.Header.StatusCode,
+		RetryAfter: parseRetryAfter(resp.HTTPHeader),
+		Attempts:   resp.Attempts,
+	}
+
+	if resp.Header.Messages != nil {
+		err.Messages = resp.Header.Messages.Error
+	}
+
+	return err
+}
+
+func invalidResponse(module string, action string, resp interface{}) error {
+	return &DecodeError{
+		Module: module,
+		Action: action,
+		Err:    fmt.Errorf("%w: %T", ErrInvalidResponse, resp),
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -21,22 +22,23 @@ func main() {
 	}
 
 	credentials := scoro.Credentials{ApiKey: *apiKey, CompanyID: *company, Subdomain: *subdomain}
+	client := scoro.NewClient(credentials)
 
 	fmt.Println("List contacts: ")
-	listContacts(credentials)
+	listContacts(client)
 
 	fmt.Println("Create contact: ")
-	contact := createContact(credentials)
+	contact := createContact(client)
 
 	fmt.Println("Modify contact: ")
-	contact = modifyContact(credentials, contact)
+	contact = modifyContact(client, contact)
 
 	fmt.Println("Remove contact: ")
-	removeContact(credentials, contact)
+	removeContact(client, contact)
 }
 
-func listContacts(credentials scoro.Credentials) {
-	contacts, err := scoro.Contacts(credentials).List(nil, 0, 3)
+func listContacts(client *scoro.Client) {
+	contacts, err := client.Contacts().List(context.Background(), nil, 0, 3)
 	if err != nil {
 		panic(err)
 	}
@@ -44,7 +46,7 @@ func listContacts(credentials scoro.Credentials) {
 	printObject(contacts)
 }
 
-func createContact(credentials scoro.Credentials) scoro.Contact {
+func createContact(client *scoro.Client) scoro.Contact {
 	contact := scoro.Contact{
 		Name:         "Viktor",
 		Lastname:     "Ladochkin",
@@ -55,7 +57,7 @@ func createContact(credentials scoro.Credentials) scoro.Contact {
 		Sex:          "M",
 	}
 
-	result, err := scoro.Contacts(credentials).Modify(contact)
+	result, err := client.Contacts().Modify(context.Background(), contact)
 	if err != nil {
 		panic(err)
 	}
@@ -64,7 +66,7 @@ func createContact(credentials scoro.Credentials) scoro.Contact {
 	return *result
 }
 
-func modifyContact(credentials scoro.Credentials, contact scoro.Contact) scoro.Contact {
+func modifyContact(client *scoro.Client, contact scoro.Contact) scoro.Contact {
 	contact.Addresses = []scoro.Address{
 		scoro.Address{
 			City:    "Tomsk",
@@ -72,7 +74,7 @@ func modifyContact(credentials scoro.Credentials, contact scoro.Contact) scoro.C
 		},
 	}
 
-	result, err := scoro.Contacts(credentials).Modify(contact)
+	result, err := client.Contacts().Modify(context.Background(), contact)
 	if err != nil {
 		panic(err)
 	}
@@ -81,8 +83,8 @@ func modifyContact(credentials scoro.Credentials, contact scoro.Contact) scoro.C
 	return *result
 }
 
-func removeContact(credentials scoro.Credentials, contact scoro.Contact) {
-	err := scoro.Contacts(credentials).Delete(*contact.ContactID)
+func removeContact(client *scoro.Client, contact scoro.Contact) {
+	err := client.Contacts().Delete(context.Background(), *contact.ContactID)
 	if err != nil {
 		panic(err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -21,22 +22,23 @@ func main() {
 	}
 
 	credentials := scoro.Credentials{ApiKey: *apiKey, CompanyID: *company, Subdomain: *subdomain}
+	client := scoro.NewClient(credentials)
 
 	fmt.Println("List products: ")
-	listProducts(credentials)
+	listProducts(client)
 
 	fmt.Println("Create product: ")
-	product := createProduct(credentials)
+	product := createProduct(client)
 
 	fmt.Println("Modify product: ")
-	product = modifyProduct(credentials, product)
+	product = modifyProduct(client, product)
 
 	fmt.Println("Remove product: ")
-	removeProduct(credentials, product)
+	removeProduct(client, product)
 }
 
-func listProducts(credentials scoro.Credentials) {
-	products, err := scoro.Products(credentials).List(nil, 0, 3)
+func listProducts(client *scoro.Client) {
+	products, err := client.Products().List(context.Background(), nil, 0, 3)
 	if err != nil {
 		panic(err)
 	}
@@ -44,7 +46,7 @@ func listProducts(credentials scoro.Credentials) {
 	printObject(products)
 }
 
-func createProduct(credentials scoro.Credentials) scoro.Product {
+func createProduct(client *scoro.Client) scoro.Product {
 	product := scoro.Product{
 		Code:         "435345",
 		Description:  scoro.MakeStrings("go-scoro example product description", scoro.DefaultLang),
@@ -53,7 +55,7 @@ func createProduct(credentials scoro.Credentials) scoro.Product {
 		Names:        scoro.MakeStrings("Go scoro product", scoro.DefaultLang),
 	}
 
-	result, err := scoro.Products(credentials).Modify(product)
+	result, err := client.Products().Modify(context.Background(), product)
 	if err != nil {
 		panic(err)
 	}
@@ -62,10 +64,10 @@ func createProduct(credentials scoro.Credentials) scoro.Product {
 	return *result
 }
 
-func modifyProduct(credentials scoro.Credentials, product scoro.Product) scoro.Product {
+func modifyProduct(client *scoro.Client, product scoro.Product) scoro.Product {
 	product.Description = scoro.MakeStrings("go-scoro changed product description", scoro.DefaultLang)
 
-	result, err := scoro.Products(credentials).Modify(product)
+	result, err := client.Products().Modify(context.Background(), product)
 	if err != nil {
 		panic(err)
 	}
@@ -74,8 +76,8 @@ func modifyProduct(credentials scoro.Credentials, product scoro.Product) scoro.P
 	return *result
 }
 
-func removeProduct(credentials scoro.Credentials, product scoro.Product) {
-	err := scoro.Products(credentials).Delete(*product.Id)
+func removeProduct(client *scoro.Client, product scoro.Product) {
+	err := client.Products().Delete(context.Background(), *product.Id)
 	if err != nil {
 		panic(err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -21,21 +22,22 @@ func main() {
 	}
 
 	credentials := scoro.Credentials{ApiKey: *apiKey, CompanyID: *company, Subdomain: *subdomain}
+	client := scoro.NewClient(credentials)
 
 	fmt.Println("Create product: ")
-	product := createProduct(credentials)
+	product := createProduct(client)
 
 	fmt.Println("Create quote: ")
-	quote := createQuote(credentials, product)
+	quote := createQuote(client, product)
 
 	fmt.Println("Remove quote: ")
-	removeQuote(credentials, quote)
+	removeQuote(client, quote)
 
 	fmt.Println("Remove product: ")
-	removeProduct(credentials, product)
+	removeProduct(client, product)
 }
 
-func createProduct(credentials scoro.Credentials) scoro.Product {
+func createProduct(client *scoro.Client) scoro.Product {
 	product := scoro.Product{
 		Code:         "435345",
 		Description:  scoro.MakeStrings("go-scoro example product description", scoro.DefaultLang),
@@ -44,7 +46,7 @@ func createProduct(credentials scoro.Credentials) scoro.Product {
 		Names:        scoro.MakeStrings("Go scoro product", scoro.DefaultLang),
 	}
 
-	result, err := scoro.Products(credentials).Modify(product)
+	result, err := client.Products().Modify(context.Background(), product)
 	if err != nil {
 		panic(err)
 	}
@@ -53,7 +55,7 @@ func createProduct(credentials scoro.Credentials) scoro.Product {
 	return *result
 }
 
-func createQuote(credentials scoro.Credentials, product scoro.Product) scoro.Quote {
+func createQuote(client *scoro.Client, product scoro.Product) scoro.Quote {
 	quote := scoro.Quote{
 		Currency:    "USD",
 		Description: "Sample description",
@@ -70,7 +72,7 @@ func createQuote(credentials scoro.Credentials, product scoro.Product) scoro.Quo
 		},
 	}
 
-	result, err := scoro.Quotes(credentials).Modify(quote)
+	result, err := client.Quotes().Modify(context.Background(), quote)
 	if err != nil {
 		panic(err)
 	}
@@ -79,8 +81,8 @@ func createQuote(credentials scoro.Credentials, product scoro.Product) scoro.Quo
 	return *result
 }
 
-func removeProduct(credentials scoro.Credentials, product scoro.Product) {
-	err := scoro.Products(credentials).Delete(*product.Id)
+func removeProduct(client *scoro.Client, product scoro.Product) {
+	err := client.Products().Delete(context.Background(), *product.Id)
 	if err != nil {
 		panic(err)
 	}
@@ -88,8 +90,8 @@ func removeProduct(credentials scoro.Credentials, product scoro.Product) {
 	fmt.Println("OK!")
 }
 
-func removeQuote(credentials scoro.Credentials, quote scoro.Quote) {
-	err := scoro.Quotes(credentials).Delete(*quote.Id)
+func removeQuote(client *scoro.Client, quote scoro.Quote) {
+	err := client.Quotes().Delete(context.Background(), *quote.Id)
 	if err != nil {
 		panic(err)
 	}
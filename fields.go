@@ -0,0 +1,134 @@
+package scoro
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Private
+
+// diffFields returns JSON fields of after which differ from marshalled
+// fields before, fields omitted by after are returned with zero values.
+func diffFields[T any](before map[string]json.RawMessage, after T) (map[string]json.RawMessage, error) {
+	afterFields, err := marshalFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]json.RawMessage)
+	for key, value := range afterFields {
+		if !bytes.Equal(before[key], value) {
+			changes[key] = value
+		}
+	}
+
+	zeros, err := zeroFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range before {
+		if _, ok := afterFields[key]; !ok {
+			changes[key] = zeros[key]
+		}
+	}
+
+	return changes, nil
+}
+
+// modifyChanges sends changed fields of record with specified id, new record
+// is created when id is 0.
+func modifyChanges[T any, PT Entity[T]](ctx context.Context, service FieldsModifier[T], id int, changes map[string]json.RawMessage) (*T, error) {
+	fields := make(map[string]interface{}, len(changes)+1)
+	for key, value := range changes {
+		fields[key] = value
+	}
+
+	if id != 0 {
+		idKey, err := idField[T, PT]()
+		if err != nil {
+			return nil, err
+		}
+		fields[idKey] = id
+	}
+
+	return service.ModifyFields(ctx, fields)
+}
+
+// marshalFields marshals record into map of its JSON fields.
+func marshalFields(record interface{}) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// zeroFields returns zero values of JSON fields of struct, including fields
+// omitted by omitempty.
+func zeroFields(record interface{}) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+
+	recordType := reflect.TypeOf(record)
+	if recordType.Kind() != reflect.Struct {
+		return fields, nil
+	}
+
+	for i := 0; i < recordType.NumField(); i++ {
+		field := recordType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		data, err := json.Marshal(reflect.Zero(field.Type).Interface())
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = data
+	}
+
+	return fields, nil
+}
+
+// idField returns name of JSON field holding ID of records: the field which
+// differs between empty record and record with ID set.
+func idField[T any, PT Entity[T]]() (string, error) {
+	var empty, withID T
+	PT(&withID).SetID(1)
+
+	emptyFields, err := marshalFields(empty)
+	if err != nil {
+		return "", err
+	}
+
+	idFields, err := marshalFields(withID)
+	if err != nil {
+		return "", err
+	}
+
+	for key, value := range idFields {
+		if !bytes.Equal(emptyFields[key], value) {
+			return key, nil
+		}
+	}
+
+	return "", fmt.Errorf("scoro: records of type %T have no ID field", empty)
+}
@@ -0,0 +1,279 @@
+package scoro
+
+import (
+	"time"
+)
+
+// Filter selects records returned by list requests. Filter values are sent
+// as "filter" field of request body.
+//
+// Typed filters are provided for modules supported by the library, RawFilter
+// can be used for fields they don't cover:
+//
+//		deleted := false
+//		filter := scoro.InvoiceFilter{
+//			Status:       []string{"unpaid", "partly_paid"},
+//			ModifiedDate: scoro.TimeRange{From: lastSync},
+//			IsDeleted:    &deleted,
+//		}
+//		invoices, err := client.Invoices().ListAll(ctx, filter)
+type Filter interface {
+	FilterValues() map[string]interface{}
+}
+
+// RawFilter holds filter fields as is.
+//
+//		products, err := client.Products().List(ctx, scoro.RawFilter{"tag": "sale"}, 1, 10)
+type RawFilter map[string]interface{}
+
+// FilterValues implements Filter.
+func (t RawFilter) FilterValues() map[string]interface{} {
+	return t
+}
+
+// DateRange filters date fields, zero bounds are omitted. Both bounds are
+// inclusive.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// TimeRange filters date/time fields like modified_date, zero bounds are
+// omitted. Both bounds are inclusive.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ProductFilter filters products.
+type ProductFilter struct {
+	Code           string
+	Name           string
+	Tag            string
+	ProductGroupID []int
+	SupplierID     []int
+	IsActive       *bool
+	IsService      *bool
+	ModifiedDate   TimeRange
+	IsDeleted      *bool
+	CustomFields   map[string]string
+}
+
+// FilterValues implements Filter.
+func (t ProductFilter) FilterValues() map[string]interface{} {
+	values := make(filterValues)
+	values.setString("code", t.Code)
+	values.setString("name", t.Name)
+	values.setString("tag", t.Tag)
+	values.setIDs("productgroup_id", t.ProductGroupID)
+	values.setIDs("supplier_id", t.SupplierID)
+	values.setBool("is_active", t.IsActive)
+	values.setBool("is_service", t.IsService)
+	values.setTimeRange("modified_date", t.ModifiedDate)
+	values.setBool("is_deleted", t.IsDeleted)
+	values.setCustomFields(t.CustomFields)
+
+	return values
+}
+
+// DocumentFilter filters sales documents: quotes, orders and invoices.
+type DocumentFilter struct {
+	No           string
+	Status       []string
+	CompanyID    []int
+	PersonID     []int
+	ProjectID    []int
+	OwnerID      []int
+	Currency     string
+	Date         DateRange
+	Deadline     DateRange
+	IsSent       *bool
+	ModifiedDate TimeRange
+	IsDeleted    *bool
+	CustomFields map[string]string
+}
+
+// FilterValues implements Filter.
+func (t DocumentFilter) FilterValues() map[string]interface{} {
+	values := make(filterValues)
+	values.setString("no", t.No)
+	values.setStrings("status", t.Status)
+	values.setIDs("company_id", t.CompanyID)
+	values.setIDs("person_id", t.PersonID)
+	values.setIDs("project_id", t.ProjectID)
+	values.setIDs("owner_id", t.OwnerID)
+	values.setString("currency", t.Currency)
+	values.setDateRange("date", t.Date)
+	values.setDateRange("deadline", t.Deadline)
+	values.setBool("is_sent", t.IsSent)
+	values.setTimeRange("modified_date", t.ModifiedDate)
+	values.setBool("is_deleted", t.IsDeleted)
+	values.setCustomFields(t.CustomFields)
+
+	return values
+}
+
+// QuoteFilter filters quotes.
+type QuoteFilter = DocumentFilter
+
+// OrderFilter filters orders.
+type OrderFilter = DocumentFilter
+
+// InvoiceFilter filters invoices and prepayments.
+type InvoiceFilter = DocumentFilter
+
+// ContactFilter filters contacts.
+type ContactFilter struct {
+	Name         string
+	Lastname     string
+	ContactType  string
+	IdCode       string
+	ReferenceNo  string
+	VatNo        string
+	ManagerID    []int
+	IsSupplier   *bool
+	IsClient     *bool
+	ModifiedDate TimeRange
+	IsDeleted    *bool
+	CustomFields map[string]string
+}
+
+// FilterValues implements Filter.
+func (t ContactFilter) FilterValues() map[string]interface{} {
+	values := make(filterValues)
+	values.setString("name", t.Name)
+	values.setString("lastname", t.Lastname)
+	values.setString("contact_type", t.ContactType)
+	values.setString("id_code", t.IdCode)
+	values.setString("reference_no", t.ReferenceNo)
+	values.setString("vatno", t.VatNo)
+	values.setIDs("manager_id", t.ManagerID)
+	values.setBool("is_supplier", t.IsSupplier)
+	values.setBool("is_client", t.IsClient)
+	values.setTimeRange("modified_date", t.ModifiedDate)
+	values.setBool("is_deleted", t.IsDeleted)
+	values.setCustomFields(t.CustomFields)
+
+	return values
+}
+
+// ReceiptFilter filters receipts.
+type ReceiptFilter struct {
+	InvoiceID    []int
+	PrepaymentID []int
+	ContactID    []int
+	SalesDocType string
+	Date         DateRange
+}
+
+// FilterValues implements Filter.
+func (t ReceiptFilter) FilterValues() map[string]interface{} {
+	values := make(filterValues)
+	values.setIDs("invoice_id", t.InvoiceID)
+	values.setIDs("prepayment_id", t.PrepaymentID)
+	values.setIDs("contact_id", t.ContactID)
+	values.setString("sales_doc_type", t.SalesDocType)
+	values.setDateRange("date", t.Date)
+
+	return values
+}
+
+// RelationFilter filters relations.
+type RelationFilter struct {
+	ObjectID []int
+	Type     string
+}
+
+// FilterValues implements Filter.
+func (t RelationFilter) FilterValues() map[string]interface{} {
+	values := make(filterValues)
+	values.setIDs("object_id", t.ObjectID)
+	values.setString("type", t.Type)
+
+	return values
+}
+
+// Private
+
+// filterValues collects non-empty filter fields in Scoro format: lists with
+// single value are sent as scalars, booleans as "1"/"0", ranges as
+// from_date/to_date objects.
+type filterValues map[string]interface{}
+
+func (t filterValues) setString(key string, value string) {
+	if value != "" {
+		t[key] = value
+	}
+}
+
+func (t filterValues) setStrings(key string, values []string) {
+	switch len(values) {
+	case 0:
+	case 1:
+		t[key] = values[0]
+	default:
+		t[key] = values
+	}
+}
+
+func (t filterValues) setIDs(key string, ids []int) {
+	switch len(ids) {
+	case 0:
+	case 1:
+		t[key] = ids[0]
+	default:
+		t[key] = ids
+	}
+}
+
+func (t filterValues) setBool(key string, value *bool) {
+	if value != nil {
+		t[key] = Bool{Value: *value}
+	}
+}
+
+func (t filterValues) setDateRange(key string, value DateRange) {
+	t.setRange(key, value.From, value.To, DatePattern)
+}
+
+func (t filterValues) setTimeRange(key string, value TimeRange) {
+	t.setRange(key, value.From, value.To, TimePattern)
+}
+
+func (t filterValues) setRange(key string, from time.Time, to time.Time, pattern string) {
+	// Patterns are quoted for JSON marshalling.
+	layout := pattern[1 : len(pattern)-1]
+
+	bounds := make(map[string]string)
+	if !from.IsZero() {
+		bounds["from_date"] = from.Format(layout)
+	}
+	if !to.IsZero() {
+		bounds["to_date"] = to.Format(layout)
+	}
+
+	if len(bounds) > 0 {
+		t[key] = bounds
+	}
+}
+
+func (t filterValues) setCustomFields(fields map[string]string) {
+	if len(fields) > 0 {
+		t["custom_fields"] = fields
+	}
+}
+
+// filterBody converts filter into value of "filter" field of request body,
+// filters other than Filter are sent as is.
+func filterBody(filter interface{}) interface{} {
+	if filter, ok := filter.(Filter); ok && filter != nil {
+		values := filter.FilterValues()
+		if len(values) == 0 {
+			return nil
+		}
+
+		return values
+	}
+
+	return filter
+}
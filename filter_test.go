@@ -0,0 +1,102 @@
+package scoro_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestFilterValues(t *testing.T) {
+	yes, no := true, false
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter scoro.Filter
+		want   string
+	}{
+		{
+			name:   "empty filter",
+			filter: scoro.ProductFilter{},
+			want:   `{}`,
+		},
+		{
+			name: "products",
+			filter: scoro.ProductFilter{
+				Code:           "P-1",
+				ProductGroupID: []int{1},
+				SupplierID:     []int{2, 3},
+				IsActive:       &yes,
+				IsService:      &no,
+				ModifiedDate:   scoro.TimeRange{From: date},
+				CustomFields:   map[string]string{"c_color": "red"},
+			},
+			want: `{"code":"P-1","custom_fields":{"c_color":"red"},"is_active":"1","is_service":"0","modified_date":{"from_date":"2024-01-02 03:04:05"},"productgroup_id":1,"supplier_id":[2,3]}`,
+		},
+		{
+			name: "documents with single values",
+			filter: scoro.InvoiceFilter{
+				Status:    []string{"paid"},
+				CompanyID: []int{1},
+				Date:      scoro.DateRange{From: date, To: date.AddDate(0, 1, 0)},
+				IsDeleted: &no,
+			},
+			want: `{"company_id":1,"date":{"from_date":"2024-01-02","to_date":"2024-02-02"},"is_deleted":"0","status":"paid"}`,
+		},
+		{
+			name: "documents with lists",
+			filter: scoro.QuoteFilter{
+				Status:       []string{"sent", "accepted"},
+				PersonID:     []int{1, 2},
+				Deadline:     scoro.DateRange{To: date},
+				ModifiedDate: scoro.TimeRange{From: date, To: date.Add(time.Hour)},
+				IsSent:       &yes,
+			},
+			want: `{"deadline":{"to_date":"2024-01-02"},"is_sent":"1","modified_date":{"from_date":"2024-01-02 03:04:05","to_date":"2024-01-02 04:04:05"},"person_id":[1,2],"status":["sent","accepted"]}`,
+		},
+		{
+			name: "contacts",
+			filter: scoro.ContactFilter{
+				Name:        "Acme",
+				ContactType: "company",
+				ManagerID:   []int{4},
+				IsClient:    &yes,
+			},
+			want: `{"contact_type":"company","is_client":"1","manager_id":4,"name":"Acme"}`,
+		},
+		{
+			name: "receipts",
+			filter: scoro.ReceiptFilter{
+				InvoiceID:    []int{5, 6},
+				SalesDocType: "invoice",
+				Date:         scoro.DateRange{From: date},
+			},
+			want: `{"date":{"from_date":"2024-01-02"},"invoice_id":[5,6],"sales_doc_type":"invoice"}`,
+		},
+		{
+			name:   "relations",
+			filter: scoro.RelationFilter{ObjectID: []int{7}, Type: "invoice"},
+			want:   `{"object_id":7,"type":"invoice"}`,
+		},
+		{
+			name:   "raw filter",
+			filter: scoro.RawFilter{"tag": "sale", "is_active": scoro.Bool{Value: true}},
+			want:   `{"is_active":"1","tag":"sale"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.filter.FilterValues())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(data) != test.want {
+				t.Errorf("got %s, want %s", data, test.want)
+			}
+		})
+	}
+}
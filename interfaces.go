@@ -0,0 +1,66 @@
+package scoro
+
+import (
+	"context"
+)
+
+// Entity is implemented by pointers to data types of Scoro modules. It gives
+// generic code access to IDs of records.
+type Entity[T any] interface {
+	*T
+	GetID() int
+	SetID(id int)
+}
+
+// EntityService is interface of View/List/Modify/Delete actions of a single
+// module. Business logic can depend on it instead of Service, so it can be
+// tested with in-memory implementation (see scorotest.MemoryService) or mocks.
+type EntityService[T any] interface {
+	View(ctx context.Context, id string) (*T, error)
+	List(ctx context.Context, filter Filter, page int, count int) ([]T, error)
+	Modify(ctx context.Context, obj T) (*T, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// FieldsModifier is implemented by services which can send only specified
+// fields of records, like Service and scorotest.MemoryService do. It isn't
+// part of EntityService, so mocks of EntityService don't have to implement it.
+type FieldsModifier[T any] interface {
+	// ModifyFields sends only specified fields, keyed by JSON names, like
+	// Modify does. Fields must contain ID field to update existing record,
+	// its other fields keep their values.
+	ModifyFields(ctx context.Context, fields map[string]interface{}) (*T, error)
+}
+
+// ProductsService is interface of products service.
+type ProductsService = EntityService[Product]
+
+// QuotesService is interface of quotes service.
+type QuotesService = EntityService[Quote]
+
+// OrdersService is interface of orders service.
+type OrdersService = EntityService[Order]
+
+// InvoicesService is interface of invoices and prepayments services.
+type InvoicesService = EntityService[Invoice]
+
+// ContactsService is interface of contacts service.
+type ContactsService = EntityService[Contact]
+
+// ReceiptsService is interface of receipts service.
+type ReceiptsService = EntityService[Receipt]
+
+// RelationsService is interface of relations service.
+type RelationsService = EntityService[Relation]
+
+var (
+	_ ProductsService  = ProductsAPI{}
+	_ QuotesService    = QuotesAPI{}
+	_ OrdersService    = OrdersAPI{}
+	_ InvoicesService  = InvoicesAPI{}
+	_ ContactsService  = ContactsAPI{}
+	_ ReceiptsService  = ReceiptsAPI{}
+	_ RelationsService = RelationsAPI{}
+
+	_ FieldsModifier[Product] = ProductsAPI{}
+)
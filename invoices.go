@@ -1,9 +1,5 @@
 package scoro
 
-import (
-	"errors"
-)
-
 // InvoiceLine struct represents invoice lines data type of Scoro API.
 // https://api.scoro.com/api/#invoiceLinesApiDocs
 type InvoiceLine struct {
@@ -63,100 +59,46 @@ type Invoice struct {
 }
 type InvoiceList []Invoice
 
-// InvoicesAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of invoices API
-type InvoicesAPI struct {
-	credentials Credentials
-	module      string
-}
-
-// InvoicesAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of invoices API
-func Invoices(credentials Credentials) InvoicesAPI {
-	return InvoicesAPI{
-		credentials: credentials,
-		module:      "invoices",
+// GetID returns ID of the invoice, zero for new invoices.
+func (t *Invoice) GetID() int {
+	if t.Id == nil {
+		return 0
 	}
-}
 
-// InvoicesAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of prepayments API
-// https://api.scoro.com/api/#prepaymentsApiDocs
-func PrepaymentInvoices(credentials Credentials) InvoicesAPI {
-	return InvoicesAPI{
-		credentials: credentials,
-		module:      "invoices/prepayments",
-	}
+	return *t.Id
 }
 
-func (t InvoicesAPI) View(id string) (*Invoice, error) {
-	resp, err := t.Request().SetResponse(invoiceResponse{}).View(id)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*invoiceResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Invoice, nil
+// SetID sets ID of the invoice.
+func (t *Invoice) SetID(id int) {
+	t.Id = &id
 }
 
-func (t InvoicesAPI) List(filter interface{}, page int, count int) (*InvoiceList, error) {
-	resp, err := t.Request().SetResponse(invoiceListResponse{}).List(filter, page, count)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*invoiceListResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Invoices, nil
-}
-
-func (t InvoicesAPI) Modify(product Invoice) (*Invoice, error) {
-	resp, err := t.Request().SetResponse(invoiceResponse{}).Modify(product)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*invoiceResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Invoice, nil
-}
-
-func (t InvoicesAPI) Delete(id int) error {
-	_, err := t.Request().SetResponse(invoiceResponse{}).Delete(id, nil)
-
-	return err
+// GetModifiedDate returns last modification time of the invoice.
+func (t *Invoice) GetModifiedDate() Time {
+	return t.ModifiedDate
 }
 
-func (t InvoicesAPI) Request() Request {
-	return NewRequest(t.credentials, t.module)
+// GetDeletedDate returns deletion time of the invoice.
+func (t *Invoice) GetDeletedDate() Time {
+	return t.DeletedDate
 }
 
-// Private
-
-type invoiceResponse struct {
-	ResponseHeader `json:",inline"`
-	Invoice        Invoice `json:"data,omitempty"`
+// Deleted reports whether the invoice is deleted.
+func (t *Invoice) Deleted() bool {
+	return t.IsDeleted.Value
 }
 
-type invoiceListResponse struct {
-	ResponseHeader `json:",inline"`
-	Invoices       InvoiceList `json:"data,omitempty"`
-}
+// InvoicesAPI provides type safe wrappers for View/List/Modify/Delete actions
+// of invoices API
+type InvoicesAPI = Service[Invoice]
 
-func (t invoiceResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+// Invoices is shortcut for NewClient(credentials).Invoices().
+func Invoices(credentials Credentials) InvoicesAPI {
+	return NewClient(credentials).Invoices()
 }
 
-func (t invoiceListResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+// PrepaymentInvoices is shortcut for NewClient(credentials).PrepaymentInvoices().
+// https://api.scoro.com/api/#prepaymentsApiDocs
+func PrepaymentInvoices(credentials Credentials) InvoicesAPI {
+	return NewClient(credentials).PrepaymentInvoices()
 }
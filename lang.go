@@ -0,0 +1,37 @@
+package scoro
+
+import (
+	"reflect"
+)
+
+// Private
+
+var stringsType = reflect.TypeOf(Strings{})
+
+// localizeStrings walks decoded response and moves Strings values received as
+// single string to the language of the request.
+func localizeStrings(value reflect.Value, lang string) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			localizeStrings(value.Elem(), lang)
+		}
+	case reflect.Struct:
+		if value.Type() == stringsType {
+			if value.CanAddr() {
+				value.Addr().Interface().(*Strings).localize(lang)
+			}
+			return
+		}
+
+		for i := 0; i < value.NumField(); i++ {
+			if field := value.Field(i); field.CanSet() {
+				localizeStrings(field, lang)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			localizeStrings(value.Index(i), lang)
+		}
+	}
+}
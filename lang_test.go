@@ -0,0 +1,155 @@
+package scoro_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+// catalogResponse is custom response type with Strings nested in slice.
+type catalogResponse struct {
+	scoro.ResponseHeader `json:",inline"`
+	Data                 struct {
+		Lines []struct {
+			Names scoro.Strings `json:"names"`
+		} `json:"lines"`
+	} `json:"data"`
+}
+
+func TestLanguage(t *testing.T) {
+	var lang string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Lang string `json:"lang"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		lang = body.Lang
+
+		// Scoro returns single string of the request language unless
+		// translations are requested.
+		var data string
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/products/list"):
+			data = `[{"product_id":1,"names":"Nimi","description":{"eng":"Description","est":"Kirjeldus"}}]`
+		case strings.HasSuffix(r.URL.Path, "/products/view/1"):
+			data = `{"product_id":1,"names":"Nimi"}`
+		case strings.HasSuffix(r.URL.Path, "/catalog/view/1"):
+			data = `{"lines":[{"names":"Rida"},{"names":{"eng":"Line"}}]}`
+		}
+
+		fmt.Fprintf(w, `{"status":"OK","statusCode":"200","data":%v}`, data)
+	}))
+	defer srv.Close()
+
+	credentials := scoro.Credentials{ApiKey: "key", CompanyID: "company", Subdomain: "test"}
+
+	tests := []struct {
+		name       string
+		clientLang string
+		call       func(ctx context.Context, client *scoro.Client) ([]scoro.Strings, error)
+		lang       string
+		want       []map[string]string
+	}{
+		{
+			name: "default language",
+			call: func(ctx context.Context, client *scoro.Client) ([]scoro.Strings, error) {
+				product, err := client.Products().View(ctx, "1")
+				if err != nil {
+					return nil, err
+				}
+				return []scoro.Strings{product.Names}, nil
+			},
+			lang: scoro.DefaultLang,
+			want: []map[string]string{{"eng": "Nimi"}},
+		},
+		{
+			name:       "client language",
+			clientLang: "est",
+			call: func(ctx context.Context, client *scoro.Client) ([]scoro.Strings, error) {
+				products, err := client.Products().List(ctx, nil, 1, 10)
+				if err != nil {
+					return nil, err
+				}
+				return []scoro.Strings{products[0].Names, products[0].Description}, nil
+			},
+			lang: "est",
+			want: []map[string]string{{"est": "Nimi"}, {"eng": "Description", "est": "Kirjeldus"}},
+		},
+		{
+			name:       "service language",
+			clientLang: "est",
+			call: func(ctx context.Context, client *scoro.Client) ([]scoro.Strings, error) {
+				product, err := client.Products().SetLang("fin").View(ctx, "1")
+				if err != nil {
+					return nil, err
+				}
+				return []scoro.Strings{product.Names}, nil
+			},
+			lang: "fin",
+			want: []map[string]string{{"fin": "Nimi"}},
+		},
+		{
+			name:       "request language",
+			clientLang: "est",
+			call: func(ctx context.Context, client *scoro.Client) ([]scoro.Strings, error) {
+				resp, err := client.Products().SetLang("fin").Request().SetLang("rus").SetResponse(productResponse{}).View(ctx, "1")
+				if err != nil {
+					return nil, err
+				}
+				return []scoro.Strings{resp.(*productResponse).Data.Names}, nil
+			},
+			lang: "rus",
+			want: []map[string]string{{"rus": "Nimi"}},
+		},
+		{
+			name: "strings nested in slice",
+			call: func(ctx context.Context, client *scoro.Client) ([]scoro.Strings, error) {
+				resp, err := scoro.NewRequest(client, "catalog").SetLang("est").SetResponse(catalogResponse{}).View(ctx, "1")
+				if err != nil {
+					return nil, err
+				}
+
+				var result []scoro.Strings
+				for _, line := range resp.(*catalogResponse).Data.Lines {
+					result = append(result, line.Names)
+				}
+				return result, nil
+			},
+			lang: "est",
+			want: []map[string]string{{"est": "Rida"}, {"eng": "Line"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := scoro.NewClient(credentials).SetBaseURL(srv.URL + "/api")
+			if test.clientLang != "" {
+				client.SetLang(test.clientLang)
+			}
+
+			got, err := test.call(context.Background(), client)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if lang != test.lang {
+				t.Errorf("got request language %q, want %q", lang, test.lang)
+			}
+
+			var values []map[string]string
+			for _, strings := range got {
+				values = append(values, strings.Values)
+			}
+
+			if fmt.Sprint(values) != fmt.Sprint(test.want) {
+				t.Errorf("got strings %v, want %v", values, test.want)
+			}
+		})
+	}
+}
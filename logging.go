@@ -0,0 +1,107 @@
+package scoro
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// Redacted replaces credentials in logged values.
+const Redacted = "[REDACTED]"
+
+// RedactBody replaces values of apiKey and company_account_id fields of JSON
+// request body. Bodies that aren't JSON objects are returned as is.
+func RedactBody(data []byte) []byte {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return data
+	}
+
+	redacted := false
+	for _, key := range []string{"apiKey", "company_account_id"} {
+		if _, ok := fields[key]; ok {
+			fields[key] = json.RawMessage(`"` + Redacted + `"`)
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return data
+	}
+
+	result, err := json.Marshal(fields)
+	if err != nil {
+		return data
+	}
+
+	return result
+}
+
+// LogValue implements slog.LogValuer, so credentials are redacted when
+// they are logged.
+func (t Credentials) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("apiKey", Redacted),
+		slog.String("company_account_id", Redacted),
+		slog.String("subdomain", t.Subdomain),
+	)
+}
+
+// Private
+
+// logCall logs completed call: failures are logged at error level, successful
+// calls at debug level. Bodies are logged at debug level if enabled.
+func (t *Client) logCall(ctx context.Context, call *Call, resp *Response, err error, latency time.Duration) {
+	level := slog.LevelDebug
+	if err != nil {
+		level = slog.LevelError
+	}
+
+	if !t.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("module", call.Module),
+		slog.String("action", call.Action),
+		slog.String("id", call.ID),
+		slog.Duration("latency", latency),
+	}
+
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("http_status", resp.HTTPStatus),
+			slog.String("status", resp.Header.Status),
+			slog.String("status_code", resp.Header.StatusCode),
+			slog.Int("attempts", resp.Attempts),
+		)
+
+		if resp.Header.Messages != nil && len(resp.Header.Messages.Error) > 0 {
+			attrs = append(attrs, slog.Any("messages", resp.Header.Messages.Error))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if t.logBodies && t.logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs, slog.String("request_body", string(RedactBody(call.Body))))
+		if resp != nil {
+			attrs = append(attrs, slog.String("response_body", string(resp.Body)))
+		}
+	}
+
+	t.logger.LogAttrs(ctx, level, "scoro request", attrs...)
+}
+
+func (t *Client) logging(next Handler) Handler {
+	return func(ctx context.Context, call *Call) (*Response, error) {
+		start := time.Now()
+		resp, err := next(ctx, call)
+		t.logCall(ctx, call, resp, err, time.Since(start))
+
+		return resp, err
+	}
+}
@@ -0,0 +1,137 @@
+package scoro_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{
+			body: `{"apiKey":"secret-key","company_account_id":"secret-company","lang":"eng"}`,
+			want: `{"apiKey":"[REDACTED]","company_account_id":"[REDACTED]","lang":"eng"}`,
+		},
+		{
+			body: `{"lang":"eng","page":1}`,
+			want: `{"lang":"eng","page":1}`,
+		},
+		{
+			body: `not JSON`,
+			want: `not JSON`,
+		},
+	}
+
+	for _, test := range tests {
+		if got := string(scoro.RedactBody([]byte(test.body))); got != test.want {
+			t.Errorf("RedactBody(%v) = %v, want %v", test.body, got, test.want)
+		}
+	}
+}
+
+func TestLoggingRedactsCredentials(t *testing.T) {
+	credentials := scoro.Credentials{ApiKey: "secret-key", CompanyID: "secret-company", Subdomain: "test"}
+
+	// Invoices fail, other modules succeed.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/invoices/") {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"status":"ERROR","statusCode":"400","messages":{"error":["Invalid filter"]}}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+	}))
+	defer srv.Close()
+
+	list := func(ctx context.Context, client *scoro.Client, logger *slog.Logger) {
+		client.Products().List(ctx, nil, 1, 10)
+	}
+
+	fail := func(ctx context.Context, client *scoro.Client, logger *slog.Logger) {
+		client.Invoices().List(ctx, nil, 1, 10)
+	}
+
+	tests := []struct {
+		name  string
+		level slog.Level
+		setup func(client *scoro.Client)
+		call  func(ctx context.Context, client *scoro.Client, logger *slog.Logger)
+		want  []string
+	}{
+		{
+			name:  "successful call at debug level",
+			level: slog.LevelDebug,
+			call:  list,
+			want:  []string{`"level":"DEBUG"`, `"request_body":`, `"response_body":`},
+		},
+		{
+			name:  "failed call at debug level",
+			level: slog.LevelDebug,
+			call:  fail,
+			want:  []string{`"level":"ERROR"`, `"request_body":`, `"response_body":`},
+		},
+		{
+			name:  "failed call at error level",
+			level: slog.LevelError,
+			call:  fail,
+			want:  []string{`"level":"ERROR"`, `"messages":["Invalid filter"]`},
+		},
+		{
+			name:  "dry run",
+			level: slog.LevelDebug,
+			setup: func(client *scoro.Client) {
+				client.SetMode(scoro.ModeDryRun)
+			},
+			call: func(ctx context.Context, client *scoro.Client, logger *slog.Logger) {
+				client.Products().Modify(ctx, scoro.Product{Code: "A"})
+			},
+			want: []string{`"msg":"scoro dry run"`, `"request_body":`},
+		},
+		{
+			name:  "credentials",
+			level: slog.LevelDebug,
+			call: func(ctx context.Context, client *scoro.Client, logger *slog.Logger) {
+				logger.InfoContext(ctx, "tenant", "credentials", credentials)
+			},
+			want: []string{`"apiKey":"[REDACTED]"`, `"company_account_id":"[REDACTED]"`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: test.level}))
+
+			client := scoro.NewClient(credentials).SetBaseURL(srv.URL + "/api").SetLogger(logger).SetLogBodies(true)
+			if test.setup != nil {
+				test.setup(client)
+			}
+
+			test.call(context.Background(), client, logger)
+
+			log := buf.String()
+			for _, secret := range []string{credentials.ApiKey, credentials.CompanyID} {
+				if strings.Contains(log, secret) {
+					t.Errorf("log contains %q:\n%v", secret, log)
+				}
+			}
+
+			for _, want := range test.want {
+				if !strings.Contains(log, want) {
+					t.Errorf("log doesn't contain %v:\n%v", want, log)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package scoro
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// CallStats describes completed API call reported to Metrics.
+type CallStats struct {
+	// Subdomain and CompanyID identify tenant of the call.
+	Subdomain string
+	CompanyID string
+
+	Module string
+	Action string
+
+	// StatusCode holds Scoro status code of failed call, or HTTP status if
+	// response has no status code. It is empty for successful calls and
+	// transport failures.
+	StatusCode string
+
+	// Err holds error returned by the call.
+	Err error
+
+	// Latency holds duration of the call including retries.
+	Latency time.Duration
+
+	// Attempts holds number of attempts made, Attempts-1 are retries.
+	Attempts int
+}
+
+// Metrics receives stats of each completed API call. Implementation should be
+// safe for concurrent use. See promscoro package for Prometheus implementation.
+type Metrics interface {
+	ObserveCall(ctx context.Context, stats CallStats)
+}
+
+// Private
+
+func (t *Client) measuring(next Handler) Handler {
+	return func(ctx context.Context, call *Call) (*Response, error) {
+		start := time.Now()
+		resp, err := next(ctx, call)
+
+		stats := CallStats{
+			Subdomain: call.Subdomain,
+			CompanyID: call.CompanyID,
+			Module:    call.Module,
+			Action:    call.Action,
+			Err:       err,
+			Latency:   time.Since(start),
+			Attempts:  1,
+		}
+
+		if resp != nil && resp.Attempts > 0 {
+			stats.Attempts = resp.Attempts
+		}
+
+		var apiErr *APIError
+		var transportErr *TransportError
+		switch {
+		case errors.As(err, &apiErr):
+			stats.StatusCode = strconv.Itoa(apiErr.Code())
+			stats.Attempts = max(apiErr.Attempts, 1)
+		case errors.As(err, &transportErr):
+			stats.Attempts = max(transportErr.Attempts, 1)
+		}
+
+		t.metrics.ObserveCall(ctx, stats)
+
+		return resp, err
+	}
+}
@@ -0,0 +1,81 @@
+package scoro
+
+import (
+	"context"
+	"net/http"
+)
+
+// Call describes single Scoro API call passed through middleware chain.
+type Call struct {
+	// Module, Action and ID identify the call, ID is empty for list and
+	// modify actions.
+	Module string
+	Action string
+	ID     string
+
+	// Subdomain and CompanyID identify tenant of the call.
+	Subdomain string
+	CompanyID string
+
+	// Page and PerPage hold requested page of list action.
+	Page    int
+	PerPage int
+
+	// Body holds marshalled request body. It never contains credentials,
+	// they are added when request is sent. Middleware can replace Body to
+	// mutate the request.
+	Body []byte
+
+	// Header holds HTTP headers sent with the request.
+	Header http.Header
+
+	// credentials are resolved once per call, so retries of the call use the
+	// same credentials.
+	credentials Credentials
+}
+
+// Response describes result of Scoro API call passed through middleware chain.
+type Response struct {
+	// HTTPStatus and HTTPHeader hold HTTP status code and headers of the
+	// response.
+	HTTPStatus int
+	HTTPHeader http.Header
+
+	// Body holds raw response body.
+	Body []byte
+
+	// Header holds decoded response header.
+	Header ResponseHeader
+
+	// Attempts holds number of attempts made to get the response.
+	Attempts int
+}
+
+// Handler sends call to Scoro API. Besides transport failures, it returns
+// *APIError along with response when API reports an error.
+type Handler func(ctx context.Context, call *Call) (*Response, error)
+
+// Middleware wraps handler to observe, modify or short-circuit calls.
+// Middleware can return response without calling next handler, the response
+// is then decoded as if it was received from Scoro API.
+//
+// Example of logging middleware:
+//
+//		client.Use(func(next scoro.Handler) scoro.Handler {
+//			return func(ctx context.Context, call *scoro.Call) (*scoro.Response, error) {
+//				resp, err := next(ctx, call)
+//				log.Printf("%v/%v %v: %v", call.Module, call.Action, call.ID, err)
+//				return resp, err
+//			}
+//		})
+type Middleware func(next Handler) Handler
+
+// Private
+
+func chain(handler Handler, middleware []Middleware) Handler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
@@ -0,0 +1,145 @@
+package scoro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// ErrReadOnly is matched by errors of modify and delete calls rejected by
+// read-only client.
+var ErrReadOnly = errors.New("scoro: client is read-only")
+
+// Mode controls whether client sends actions changing data.
+type Mode int
+
+const (
+	// ModeReadWrite sends all actions, it is default mode of the client.
+	ModeReadWrite Mode = iota
+
+	// ModeReadOnly rejects modify and delete actions with ReadOnlyError
+	// without sending them.
+	ModeReadOnly
+
+	// ModeDryRun validates and logs modify and delete actions and returns
+	// synthetic successful result without sending them. Modify returns
+	// record from the request, which has no ID if it would be created.
+	ModeDryRun
+)
+
+func (t Mode) String() string {
+	switch t {
+	case ModeReadWrite:
+		return "read-write"
+	case ModeReadOnly:
+		return "read-only"
+	case ModeDryRun:
+		return "dry-run"
+	}
+
+	return "unknown"
+}
+
+// ReadOnlyError is returned by modify and delete calls of read-only client.
+type ReadOnlyError struct {
+	Module string
+	Action string
+	ID     string
+}
+
+func (t *ReadOnlyError) Error() string {
+	if t.ID != "" {
+		return fmt.Sprintf("scoro: %v/%v %v: client is read-only", t.Module, t.Action, t.ID)
+	}
+
+	return fmt.Sprintf("scoro: %v/%v: client is read-only", t.Module, t.Action)
+}
+
+func (t *ReadOnlyError) Unwrap() error {
+	return ErrReadOnly
+}
+
+// Private
+
+func isWriteAction(action string) bool {
+	return action == "modify" || action == "delete"
+}
+
+// guarding intercepts modify and delete calls in read-only and dry-run modes.
+// It is the innermost handler, so rejected and simulated calls are still
+// logged and measured.
+func (t *Client) guarding(next Handler) Handler {
+	return func(ctx context.Context, call *Call) (*Response, error) {
+		if !isWriteAction(call.Action) {
+			return next(ctx, call)
+		}
+
+		if t.mode == ModeReadOnly {
+			return nil, &ReadOnlyError{Module: call.Module, Action: call.Action, ID: call.ID}
+		}
+
+		return t.dryRun(ctx, call)
+	}
+}
+
+// dryRun validates request body the same way it is validated before sending,
+// logs it and returns synthetic successful response.
+func (t *Client) dryRun(ctx context.Context, call *Call) (*Response, error) {
+	envelope := envelopeFor(t.version)
+
+	if _, err := envelope.authorize(call.credentials, call.Body); err != nil {
+		return nil, &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+	}
+
+	var body struct {
+		Request json.RawMessage `json:"request"`
+	}
+	if err := json.Unmarshal(call.Body, &body); err != nil {
+		return nil, &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+	}
+
+	if call.Action == "modify" && (len(body.Request) == 0 || body.Request[0] != '{') {
+		return nil, &APIError{
+			Module:     call.Module,
+			Action:     call.Action,
+			HTTPStatus: http.StatusBadRequest,
+			StatusCode: strconv.Itoa(http.StatusBadRequest),
+			Messages:   []string{"request must be an object"},
+		}
+	}
+
+	logger := t.logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "scoro dry run",
+		slog.String("module", call.Module),
+		slog.String("action", call.Action),
+		slog.String("id", call.ID),
+		slog.String("request_body", string(call.Body)),
+	)
+
+	header := ResponseHeader{Status: "OK", StatusCode: strconv.Itoa(http.StatusOK)}
+
+	var data json.RawMessage
+	if call.Action == "modify" {
+		data = body.Request
+	}
+
+	respData, err := envelope.encodeResponse(header, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		HTTPStatus: http.StatusOK,
+		HTTPHeader: make(http.Header),
+		Body:       respData,
+		Header:     header,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/scorotest"
+)
+
+func TestModes(t *testing.T) {
+	id := func(id int) *int {
+		return &id
+	}
+
+	modify := func(product scoro.Product) func(ctx context.Context, products scoro.ProductsAPI) (*scoro.Product, error) {
+		return func(ctx context.Context, products scoro.ProductsAPI) (*scoro.Product, error) {
+			return products.Modify(ctx, product)
+		}
+	}
+
+	remove := func(ctx context.Context, products scoro.ProductsAPI) (*scoro.Product, error) {
+		return nil, products.Delete(ctx, 1)
+	}
+
+	tests := []struct {
+		name    string
+		mode    scoro.Mode
+		version scoro.APIVersion
+		call    func(ctx context.Context, products scoro.ProductsAPI) (*scoro.Product, error)
+		want    *scoro.Product
+		err     error
+	}{
+		{
+			name: "read-only client rejects create",
+			mode: scoro.ModeReadOnly,
+			call: modify(scoro.Product{Code: "B"}),
+			err:  scoro.ErrReadOnly,
+		},
+		{
+			name: "read-only client rejects update",
+			mode: scoro.ModeReadOnly,
+			call: modify(scoro.Product{Id: id(1), Name: "Renamed"}),
+			err:  scoro.ErrReadOnly,
+		},
+		{
+			name: "read-only client rejects delete",
+			mode: scoro.ModeReadOnly,
+			call: remove,
+			err:  scoro.ErrReadOnly,
+		},
+		{
+			name: "read-only client views records",
+			mode: scoro.ModeReadOnly,
+			call: func(ctx context.Context, products scoro.ProductsAPI) (*scoro.Product, error) {
+				return products.View(ctx, "1")
+			},
+			want: &scoro.Product{Id: id(1), Code: "A"},
+		},
+		{
+			name: "dry run returns created record",
+			mode: scoro.ModeDryRun,
+			call: modify(scoro.Product{Code: "B", Name: "New"}),
+			want: &scoro.Product{Code: "B", Name: "New"},
+		},
+		{
+			name: "dry run returns updated record",
+			mode: scoro.ModeDryRun,
+			call: modify(scoro.Product{Id: id(1), Name: "Renamed"}),
+			want: &scoro.Product{Id: id(1), Name: "Renamed"},
+		},
+		{
+			name:    "dry run of API v2",
+			mode:    scoro.ModeDryRun,
+			version: scoro.APIv2,
+			call:    modify(scoro.Product{Code: "B"}),
+			want:    &scoro.Product{Code: "B"},
+		},
+		{
+			name: "dry run of delete",
+			mode: scoro.ModeDryRun,
+			call: remove,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			if _, err := srv.Put("products", scoro.Product{Code: "A"}); err != nil {
+				t.Fatal(err)
+			}
+
+			var writes int
+			srv.AddHook(func(req *scorotest.Request) *scorotest.Fault {
+				if req.Action == "modify" || req.Action == "delete" {
+					writes++
+				}
+				return nil
+			})
+
+			// Dry runs are logged at info level.
+			client := srv.Client().SetMode(test.mode).SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if test.version != "" {
+				client.SetAPIVersion(test.version)
+			}
+
+			product, err := test.call(context.Background(), client.Products())
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			var readOnlyErr *scoro.ReadOnlyError
+			if err != nil && (!errors.As(err, &readOnlyErr) || readOnlyErr.Module != "products") {
+				t.Errorf("got error %#v, want ReadOnlyError of products", err)
+			}
+
+			if writes > 0 {
+				t.Errorf("%d modify and delete requests reached the server", writes)
+			}
+
+			if test.want != nil && (product == nil || product.Code != test.want.Code || product.Name != test.want.Name || (product.Id == nil) != (test.want.Id == nil)) {
+				t.Errorf("got product %+v, want %+v", product, test.want)
+			}
+
+			records := srv.Records("products")
+			if len(records) != 1 || records[0]["is_deleted"] != "0" || records[0]["name"] != nil {
+				t.Errorf("stored records are changed: %v", records)
+			}
+		})
+	}
+}
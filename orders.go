@@ -1,9 +1,5 @@
 package scoro
 
-import (
-	"errors"
-)
-
 // OrderLine struct represents order lines data type of Scoro API.
 // https://api.scoro.com/api/#orderLinesApiDocs
 type OrderLine struct {
@@ -57,84 +53,40 @@ type Order struct {
 }
 type OrderList []Order
 
-// OrdersAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of orders API
-type OrdersAPI struct {
-	credentials Credentials
-}
-
-func Orders(credentials Credentials) OrdersAPI {
-	return OrdersAPI{credentials}
-}
-
-func (t OrdersAPI) View(id string) (*Order, error) {
-	resp, err := t.Request().SetResponse(orderResponse{}).View(id)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*orderResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Order, nil
-}
-
-func (t OrdersAPI) List(filter interface{}, page int, count int) (*OrderList, error) {
-	resp, err := t.Request().SetResponse(orderListResponse{}).List(filter, page, count)
-	if err != nil {
-		return nil, err
+// GetID returns ID of the order, zero for new orders.
+func (t *Order) GetID() int {
+	if t.Id == nil {
+		return 0
 	}
 
-	result, ok := resp.(*orderListResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Orders, nil
-}
-
-func (t OrdersAPI) Modify(product Order) (*Order, error) {
-	resp, err := t.Request().SetResponse(orderResponse{}).Modify(product)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*orderResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Order, nil
+	return *t.Id
 }
 
-func (t OrdersAPI) Delete(id int) error {
-	_, err := t.Request().SetResponse(orderResponse{}).Delete(id, nil)
-
-	return err
+// SetID sets ID of the order.
+func (t *Order) SetID(id int) {
+	t.Id = &id
 }
 
-func (t OrdersAPI) Request() Request {
-	return NewRequest(t.credentials, "orders")
+// GetModifiedDate returns last modification time of the order.
+func (t *Order) GetModifiedDate() Time {
+	return t.ModifiedDate
 }
 
-// Private
-
-type orderResponse struct {
-	ResponseHeader `json:",inline"`
-	Order          Order `json:"data,omitempty"`
+// GetDeletedDate returns deletion time of the order.
+func (t *Order) GetDeletedDate() Time {
+	return t.DeletedDate
 }
 
-type orderListResponse struct {
-	ResponseHeader `json:",inline"`
-	Orders         OrderList `json:"data,omitempty"`
+// Deleted reports whether the order is deleted.
+func (t *Order) Deleted() bool {
+	return t.IsDeleted.Value
 }
 
-func (t orderResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
-}
+// OrdersAPI provides type safe wrappers for View/List/Modify/Delete actions
+// of orders API
+type OrdersAPI = Service[Order]
 
-func (t orderListResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+// Orders is shortcut for NewClient(credentials).Orders().
+func Orders(credentials Credentials) OrdersAPI {
+	return NewClient(credentials).Orders()
 }
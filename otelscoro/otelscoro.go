@@ -0,0 +1,131 @@
+// Package otelscoro provides OpenTelemetry instrumentation of go-scoro clients.
+//
+// Middleware creates span per View/List/Modify/Delete call. Spans are
+// children of the span found in the context passed to service methods, and
+// trace context is propagated to Scoro API requests.
+//
+//		client := scoro.NewClient(credentials).Use(otelscoro.Middleware())
+//
+// Tracer provider and propagator are taken from the otel global registry by
+// default and can be overridden with options, for instance, to use in-memory
+// exporter in tests:
+//
+//		exporter := tracetest.NewInMemoryExporter()
+//		provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+//		client.Use(otelscoro.Middleware(otelscoro.WithTracerProvider(provider)))
+package otelscoro
+
+import (
+	"context"
+	"net/http"
+
+	scoro "github.com/lxmx/go-scoro"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is instrumentation scope name of created tracer.
+const ScopeName = "github.com/lxmx/go-scoro/otelscoro"
+
+// Span attribute keys.
+const (
+	ModuleKey     = attribute.Key("scoro.module")
+	ActionKey     = attribute.Key("scoro.action")
+	IDKey         = attribute.Key("scoro.id")
+	PageKey       = attribute.Key("scoro.page")
+	PerPageKey    = attribute.Key("scoro.per_page")
+	StatusKey     = attribute.Key("scoro.status")
+	StatusCodeKey = attribute.Key("scoro.status_code")
+	AttemptsKey   = attribute.Key("scoro.attempts")
+	HTTPStatusKey = attribute.Key("http.response.status_code")
+)
+
+// Option configures middleware.
+type Option func(*config)
+
+// WithTracerProvider sets tracer provider used to create spans.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = provider
+	}
+}
+
+// WithPropagator sets propagator used to inject trace context into requests.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagator = propagator
+	}
+}
+
+// Middleware returns go-scoro middleware tracing API calls.
+func Middleware(options ...Option) scoro.Middleware {
+	c := config{
+		provider:   otel.GetTracerProvider(),
+		propagator: otel.GetTextMapPropagator(),
+	}
+	for _, option := range options {
+		option(&c)
+	}
+
+	tracer := c.provider.Tracer(ScopeName)
+
+	return func(next scoro.Handler) scoro.Handler {
+		return func(ctx context.Context, call *scoro.Call) (*scoro.Response, error) {
+			ctx, span := tracer.Start(ctx, "scoro "+call.Module+"/"+call.Action,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(callAttributes(call)...),
+			)
+			defer span.End()
+
+			if call.Header == nil {
+				call.Header = make(http.Header)
+			}
+			c.propagator.Inject(ctx, propagation.HeaderCarrier(call.Header))
+
+			resp, err := next(ctx, call)
+
+			if resp != nil {
+				span.SetAttributes(
+					HTTPStatusKey.Int(resp.HTTPStatus),
+					StatusKey.String(resp.Header.Status),
+					StatusCodeKey.String(resp.Header.StatusCode),
+					AttemptsKey.Int(resp.Attempts),
+				)
+			}
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return resp, err
+		}
+	}
+}
+
+// Private
+
+type config struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+func callAttributes(call *scoro.Call) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		ModuleKey.String(call.Module),
+		ActionKey.String(call.Action),
+	}
+
+	if call.ID != "" {
+		attrs = append(attrs, IDKey.String(call.ID))
+	}
+
+	if call.Action == "list" {
+		attrs = append(attrs, PageKey.Int(call.Page), PerPageKey.Int(call.PerPage))
+	}
+
+	return attrs
+}
@@ -0,0 +1,135 @@
+package otelscoro_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/otelscoro"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware(t *testing.T) {
+	// Product 1 exists, other products don't.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/products/view/1"):
+			fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":{"product_id":1,"code":"A"}}`)
+		case strings.HasSuffix(r.URL.Path, "/products/list"):
+			fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"status":"ERROR","statusCode":"404","messages":{"error":["Not found"]}}`)
+		}
+	}))
+	defer srv.Close()
+
+	credentials := scoro.Credentials{ApiKey: "key", CompanyID: "company", Subdomain: "test"}
+
+	tests := []struct {
+		name   string
+		call   func(ctx context.Context, products scoro.ProductsAPI) error
+		span   string
+		attrs  map[attribute.Key]attribute.Value
+		status codes.Code
+	}{
+		{
+			name: "view",
+			call: func(ctx context.Context, products scoro.ProductsAPI) error {
+				_, err := products.View(ctx, "1")
+				return err
+			},
+			span: "scoro products/view",
+			attrs: map[attribute.Key]attribute.Value{
+				otelscoro.ModuleKey:     attribute.StringValue("products"),
+				otelscoro.ActionKey:     attribute.StringValue("view"),
+				otelscoro.IDKey:         attribute.StringValue("1"),
+				otelscoro.HTTPStatusKey: attribute.IntValue(200),
+				otelscoro.StatusKey:     attribute.StringValue("OK"),
+				otelscoro.AttemptsKey:   attribute.IntValue(1),
+			},
+			status: codes.Unset,
+		},
+		{
+			name: "list",
+			call: func(ctx context.Context, products scoro.ProductsAPI) error {
+				_, err := products.List(ctx, nil, 2, 5)
+				return err
+			},
+			span: "scoro products/list",
+			attrs: map[attribute.Key]attribute.Value{
+				otelscoro.ActionKey:  attribute.StringValue("list"),
+				otelscoro.PageKey:    attribute.IntValue(2),
+				otelscoro.PerPageKey: attribute.IntValue(5),
+			},
+			status: codes.Unset,
+		},
+		{
+			name: "error",
+			call: func(ctx context.Context, products scoro.ProductsAPI) error {
+				_, err := products.View(ctx, "99")
+				return err
+			},
+			span: "scoro products/view",
+			attrs: map[attribute.Key]attribute.Value{
+				otelscoro.HTTPStatusKey: attribute.IntValue(404),
+				otelscoro.StatusKey:     attribute.StringValue("ERROR"),
+				otelscoro.StatusCodeKey: attribute.StringValue("404"),
+			},
+			status: codes.Error,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+			products := scoro.NewClient(credentials).SetBaseURL(srv.URL + "/api").Use(otelscoro.Middleware(otelscoro.WithTracerProvider(provider))).Products()
+
+			ctx, parent := provider.Tracer("test").Start(context.Background(), "parent")
+			test.call(ctx, products)
+			parent.End()
+
+			spans := exporter.GetSpans()
+			if len(spans) != 2 {
+				t.Fatalf("got %d spans, want call and parent spans", len(spans))
+			}
+
+			span := spans[0]
+			if span.Name != test.span {
+				t.Errorf("got span %q, want %q", span.Name, test.span)
+			}
+
+			if span.SpanKind != trace.SpanKindClient {
+				t.Errorf("got span kind %v, want client", span.SpanKind)
+			}
+
+			if span.Parent.SpanID() != spans[1].SpanContext.SpanID() {
+				t.Error("span isn't child of the span in context")
+			}
+
+			if span.Status.Code != test.status {
+				t.Errorf("got status %v, want %v", span.Status.Code, test.status)
+			}
+
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, attr := range span.Attributes {
+				attrs[attr.Key] = attr.Value
+			}
+
+			for key, want := range test.attrs {
+				if got, ok := attrs[key]; !ok || got != want {
+					t.Errorf("got attribute %v = %v, want %v", key, got.Emit(), want.Emit())
+				}
+			}
+		})
+	}
+}
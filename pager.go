@@ -0,0 +1,125 @@
+package scoro
+
+import (
+	"context"
+	"iter"
+)
+
+// DefaultPageSize is number of records requested per page by pagers.
+const DefaultPageSize = 100
+
+// PageFunc loads single page of records, page numbers start from 1.
+type PageFunc[T any] func(ctx context.Context, filter Filter, page int, count int) ([]T, error)
+
+// Pager iterates over records matching filter, loading them page by page.
+// Iteration stops on the first page shorter than page size, after max items
+// limit is reached or when the caller breaks the loop.
+//
+// Example:
+//
+//		pager := client.Invoices().Pager(filter).SetPageSize(50)
+//		for invoice, err := range pager.All(ctx) {
+//			if err != nil {
+//				return err
+//			}
+//			...
+//		}
+type Pager[T any] struct {
+	list     PageFunc[T]
+	filter   Filter
+	pageSize int
+	maxItems int
+}
+
+// NewPager creates pager loading records with specified list function.
+func NewPager[T any](list PageFunc[T], filter Filter) *Pager[T] {
+	return &Pager[T]{
+		list:     list,
+		filter:   filter,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// SetPageSize sets number of records requested per page.
+func (t *Pager[T]) SetPageSize(pageSize int) *Pager[T] {
+	if pageSize > 0 {
+		t.pageSize = pageSize
+	}
+	return t
+}
+
+// SetMaxItems limits total number of returned records, zero means no limit.
+func (t *Pager[T]) SetMaxItems(maxItems int) *Pager[T] {
+	t.maxItems = maxItems
+	return t
+}
+
+// Pages returns iterator over pages of records. Iteration stops after the
+// first error.
+func (t *Pager[T]) Pages(ctx context.Context) iter.Seq2[[]T, error] {
+	return func(yield func([]T, error) bool) {
+		total := 0
+
+		for page := 1; ; page++ {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			items, err := t.list(ctx, t.filter, page, t.pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			last := len(items) < t.pageSize
+			if t.maxItems > 0 && total+len(items) >= t.maxItems {
+				items = items[:t.maxItems-total]
+				last = true
+			}
+			total += len(items)
+
+			if len(items) > 0 && !yield(items, nil) {
+				return
+			}
+
+			if last {
+				return
+			}
+		}
+	}
+}
+
+// All returns iterator over records. Iteration stops after the first error.
+func (t *Pager[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for items, err := range t.Pages(ctx) {
+			if err != nil {
+				var empty T
+				yield(empty, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect loads all records into slice.
+func (t *Pager[T]) Collect(ctx context.Context) ([]T, error) {
+	var result []T
+
+	for items, err := range t.Pages(ctx) {
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, items...)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package scoro
+
+import (
+	"context"
+	"strconv"
+)
+
+// PatchService is part of service used by Patch.
+type PatchService[T any] interface {
+	View(ctx context.Context, id string) (*T, error)
+	FieldsModifier[T]
+}
+
+// Patch loads record of service with specified id, applies change to it and
+// sends only fields which have been changed, so fields without real
+// emptiness (like Bool, Date, Time and Decimal) don't overwrite data which
+// hasn't been touched. Patch returns updated record, current record is
+// returned without request if change doesn't modify anything.
+//
+//		product, err := scoro.Patch(ctx, client.Products(), id, func(product *scoro.Product) {
+//			product.Name = "New name"
+//		})
+//
+// Fields cleared by change are sent with zero values.
+func Patch[T any, PT Entity[T]](ctx context.Context, service PatchService[T], id int, change func(record *T)) (*T, error) {
+	current, err := service.View(ctx, strconv.Itoa(id))
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := marshalFields(*current)
+	if err != nil {
+		return nil, err
+	}
+
+	change(current)
+
+	changes, err := diffFields(before, *current)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) == 0 {
+		return current, nil
+	}
+
+	return modifyChanges[T, PT](ctx, service, id, changes)
+}
@@ -0,0 +1,87 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestPatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     int
+		change func(contact *scoro.Contact)
+		sent   []string
+		want   scoro.Contact
+		err    error
+	}{
+		{
+			name:   "changed field is sent",
+			id:     1,
+			change: func(contact *scoro.Contact) { contact.Name = "Jane" },
+			sent:   []string{"contact_id", "name"},
+			want:   scoro.Contact{Name: "Jane", Lastname: "Doe", IsClient: scoro.Bool{Value: true}},
+		},
+		{
+			name:   "cleared field is sent",
+			id:     1,
+			change: func(contact *scoro.Contact) { contact.Lastname = "" },
+			sent:   []string{"contact_id", "lastname"},
+			want:   scoro.Contact{Name: "John", IsClient: scoro.Bool{Value: true}},
+		},
+		{
+			name:   "bool field is changed",
+			id:     1,
+			change: func(contact *scoro.Contact) { contact.IsClient = scoro.Bool{} },
+			sent:   []string{"contact_id", "is_client"},
+			want:   scoro.Contact{Name: "John", Lastname: "Doe"},
+		},
+		{
+			name:   "unchanged record isn't sent",
+			id:     1,
+			change: func(contact *scoro.Contact) {},
+			want:   scoro.Contact{Name: "John", Lastname: "Doe", IsClient: scoro.Bool{Value: true}},
+		},
+		{
+			name:   "missing record",
+			id:     99,
+			change: func(contact *scoro.Contact) { contact.Name = "Jane" },
+			err:    scoro.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			if _, err := srv.Put("contacts", scoro.Contact{Name: "John", Lastname: "Doe", IsClient: scoro.Bool{Value: true}}); err != nil {
+				t.Fatal(err)
+			}
+			modified := captureModify(srv)
+
+			contact, err := scoro.Patch(context.Background(), srv.Client().Contacts(), test.id, test.change)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			var sent []string
+			if requests := modified(); len(requests) > 0 {
+				sent = fieldNames(requests[0])
+			}
+
+			if fmt.Sprint(sent) != fmt.Sprint(test.sent) {
+				t.Errorf("got sent fields %v, want %v", sent, test.sent)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if contact.Name != test.want.Name || contact.Lastname != test.want.Lastname || contact.IsClient != test.want.IsClient {
+				t.Errorf("got %v %v %v, want %v %v %v", contact.Name, contact.Lastname, contact.IsClient, test.want.Name, test.want.Lastname, test.want.IsClient)
+			}
+		})
+	}
+}
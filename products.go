@@ -1,9 +1,5 @@
 package scoro
 
-import (
-	"errors"
-)
-
 // Product struct represents products data type of Scoro API.
 // https://api.scoro.com/api/#productsApiDocs
 type Product struct {
@@ -30,84 +26,40 @@ type Product struct {
 }
 type ProductList []Product
 
-// ProductsAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of products API
-type ProductsAPI struct {
-	credentials Credentials
-}
-
-func Products(credentials Credentials) ProductsAPI {
-	return ProductsAPI{credentials}
-}
-
-func (t ProductsAPI) View(id string) (*Product, error) {
-	resp, err := t.Request().SetResponse(productResponse{}).View(id)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*productResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Product, nil
-}
-
-func (t ProductsAPI) List(filter interface{}, page int, count int) (*ProductList, error) {
-	resp, err := t.Request().SetResponse(productListResponse{}).List(filter, page, count)
-	if err != nil {
-		return nil, err
+// GetID returns ID of the product, zero for new products.
+func (t *Product) GetID() int {
+	if t.Id == nil {
+		return 0
 	}
 
-	result, ok := resp.(*productListResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Products, nil
-}
-
-func (t ProductsAPI) Modify(product Product) (*Product, error) {
-	resp, err := t.Request().SetResponse(productResponse{}).Modify(product)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*productResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Product, nil
+	return *t.Id
 }
 
-func (t ProductsAPI) Delete(id int) error {
-	_, err := t.Request().SetResponse(productResponse{}).Delete(id, nil)
-
-	return err
+// SetID sets ID of the product.
+func (t *Product) SetID(id int) {
+	t.Id = &id
 }
 
-func (t ProductsAPI) Request() Request {
-	return NewRequest(t.credentials, "products")
+// GetModifiedDate returns last modification time of the product.
+func (t *Product) GetModifiedDate() Time {
+	return t.ModifiedDate
 }
 
-// Private
-
-type productResponse struct {
-	ResponseHeader `json:",inline"`
-	Product        Product `json:"data,omitempty"`
+// GetDeletedDate returns deletion time of the product.
+func (t *Product) GetDeletedDate() Time {
+	return t.DeletedDate
 }
 
-type productListResponse struct {
-	ResponseHeader `json:",inline"`
-	Products       ProductList `json:"data,omitempty"`
+// Deleted reports whether the product is deleted.
+func (t *Product) Deleted() bool {
+	return t.IsDeleted.Value
 }
 
-func (t productResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
-}
+// ProductsAPI provides type safe wrappers for View/List/Modify/Delete actions
+// of products API
+type ProductsAPI = Service[Product]
 
-func (t productListResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+// Products is shortcut for NewClient(credentials).Products().
+func Products(credentials Credentials) ProductsAPI {
+	return NewClient(credentials).Products()
 }
@@ -0,0 +1,149 @@
+// Package promscoro provides Prometheus metrics of go-scoro API usage.
+//
+// Collector implements both scoro.Metrics and prometheus.Collector, so it is
+// registered in Prometheus registry and set as client metrics hook:
+//
+//		collector := promscoro.NewCollector(promscoro.CollectorOpts{})
+//		prometheus.MustRegister(collector)
+//
+//		client := scoro.NewClient(credentials).SetMetrics(collector)
+//
+// All metrics are labeled by tenant (subdomain and company), module and action:
+//
+//	- scoro_requests_total counts API calls
+//	- scoro_request_duration_seconds observes latency of API calls including retries
+//	- scoro_errors_total counts failed calls, additionally labeled by status code
+//	  or kind of error: read_only, circuit_open, decode, transport or other
+//	- scoro_retries_total counts retried attempts
+package promscoro
+
+import (
+	"context"
+	"errors"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CollectorOpts configures collector.
+type CollectorOpts struct {
+	// Namespace of metric names, "scoro" by default.
+	Namespace string
+
+	// Buckets of latency histogram, prometheus.DefBuckets by default.
+	Buckets []float64
+
+	// ConstLabels are added to all metrics.
+	ConstLabels prometheus.Labels
+}
+
+// Collector collects metrics of API calls.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+}
+
+// NewCollector creates collector with specified options.
+func NewCollector(opts CollectorOpts) *Collector {
+	if opts.Namespace == "" {
+		opts.Namespace = "scoro"
+	}
+
+	if opts.Buckets == nil {
+		opts.Buckets = prometheus.DefBuckets
+	}
+
+	labels := []string{"subdomain", "company", "module", "action"}
+
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "requests_total",
+			Help:        "Number of Scoro API calls.",
+			ConstLabels: opts.ConstLabels,
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   opts.Namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of Scoro API calls including retries.",
+			Buckets:     opts.Buckets,
+			ConstLabels: opts.ConstLabels,
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "errors_total",
+			Help:        "Number of failed Scoro API calls by status code.",
+			ConstLabels: opts.ConstLabels,
+		}, append(labels, "status_code")),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Name:        "retries_total",
+			Help:        "Number of retried Scoro API attempts.",
+			ConstLabels: opts.ConstLabels,
+		}, labels),
+	}
+}
+
+// ObserveCall implements scoro.Metrics.
+func (t *Collector) ObserveCall(ctx context.Context, stats scoro.CallStats) {
+	labels := prometheus.Labels{
+		"subdomain": stats.Subdomain,
+		"company":   stats.CompanyID,
+		"module":    stats.Module,
+		"action":    stats.Action,
+	}
+
+	t.requests.With(labels).Inc()
+	t.duration.With(labels).Observe(stats.Latency.Seconds())
+
+	if stats.Attempts > 1 {
+		t.retries.With(labels).Add(float64(stats.Attempts - 1))
+	}
+
+	if stats.Err != nil {
+		labels["status_code"] = errorLabel(stats)
+		t.errors.With(labels).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (t *Collector) Describe(ch chan<- *prometheus.Desc) {
+	t.requests.Describe(ch)
+	t.duration.Describe(ch)
+	t.errors.Describe(ch)
+	t.retries.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (t *Collector) Collect(ch chan<- prometheus.Metric) {
+	t.requests.Collect(ch)
+	t.duration.Collect(ch)
+	t.errors.Collect(ch)
+	t.retries.Collect(ch)
+}
+
+// Private
+
+// errorLabel returns status code of failed call, or kind of error if there
+// is no status code.
+func errorLabel(stats scoro.CallStats) string {
+	var decodeErr *scoro.DecodeError
+	var transportErr *scoro.TransportError
+
+	switch {
+	case stats.StatusCode != "":
+		return stats.StatusCode
+	case errors.Is(stats.Err, scoro.ErrReadOnly):
+		return "read_only"
+	case errors.Is(stats.Err, scoro.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.As(stats.Err, &decodeErr):
+		return "decode"
+	case errors.As(stats.Err, &transportErr):
+		return "transport"
+	}
+
+	return "other"
+}
@@ -0,0 +1,237 @@
+package promscoro_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/promscoro"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCollector(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+	}
+
+	unavailable := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"status":"ERROR","statusCode":"503"}`)
+	}
+
+	tests := []struct {
+		name    string
+		respond []http.HandlerFunc
+		setup   func(client *scoro.Client)
+		calls   int
+		modify  bool
+		want    []string
+	}{
+		{
+			name:    "successful call",
+			respond: []http.HandlerFunc{ok},
+			want: []string{
+				"scoro_request_duration_seconds_count 1",
+				"scoro_requests_total 1",
+			},
+		},
+		{
+			name:    "retried call",
+			respond: []http.HandlerFunc{unavailable, ok},
+			setup: func(client *scoro.Client) {
+				client.SetRetryPolicy(scoro.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+			},
+			want: []string{
+				"scoro_request_duration_seconds_count 1",
+				"scoro_requests_total 1",
+				"scoro_retries_total 1",
+			},
+		},
+		{
+			name: "API error",
+			respond: []http.HandlerFunc{func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"status":"ERROR","statusCode":"400","messages":{"error":["Invalid filter"]}}`)
+			}},
+			want: []string{
+				`scoro_errors_total{status_code="400"} 1`,
+				"scoro_request_duration_seconds_count 1",
+				"scoro_requests_total 1",
+			},
+		},
+		{
+			name: "decode error",
+			respond: []http.HandlerFunc{func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{`)
+			}},
+			want: []string{
+				`scoro_errors_total{status_code="decode"} 1`,
+				"scoro_request_duration_seconds_count 1",
+				"scoro_requests_total 1",
+			},
+		},
+		{
+			name: "transport error",
+			respond: []http.HandlerFunc{func(w http.ResponseWriter, r *http.Request) {
+				panic(http.ErrAbortHandler)
+			}},
+			want: []string{
+				`scoro_errors_total{status_code="transport"} 1`,
+				"scoro_request_duration_seconds_count 1",
+				"scoro_requests_total 1",
+			},
+		},
+		{
+			name:    "open circuit",
+			respond: []http.HandlerFunc{unavailable},
+			setup: func(client *scoro.Client) {
+				client.SetCircuitBreaker(scoro.NewCircuitBreaker(1, time.Hour))
+			},
+			calls: 2,
+			want: []string{
+				`scoro_errors_total{status_code="503"} 1`,
+				`scoro_errors_total{status_code="circuit_open"} 1`,
+				"scoro_request_duration_seconds_count 2",
+				"scoro_requests_total 2",
+			},
+		},
+		{
+			name: "read-only client",
+			setup: func(client *scoro.Client) {
+				client.SetMode(scoro.ModeReadOnly)
+			},
+			modify: true,
+			want: []string{
+				`scoro_errors_total{status_code="read_only"} 1`,
+				"scoro_request_duration_seconds_count 1",
+				"scoro_requests_total 1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var requests atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(requests.Add(1))
+				test.respond[min(n, len(test.respond))-1](w, r)
+			}))
+			defer srv.Close()
+
+			collector := promscoro.NewCollector(promscoro.CollectorOpts{})
+			registry := prometheus.NewPedanticRegistry()
+			registry.MustRegister(collector)
+
+			credentials := scoro.Credentials{ApiKey: "key", CompanyID: "company", Subdomain: "test"}
+			client := scoro.NewClient(credentials).SetBaseURL(srv.URL + "/api").SetMetrics(collector)
+			if test.setup != nil {
+				test.setup(client)
+			}
+
+			for i := 0; i < max(test.calls, 1); i++ {
+				if test.modify {
+					client.Products().Modify(context.Background(), scoro.Product{Code: "A"})
+				} else {
+					client.Products().List(context.Background(), nil, 1, 10)
+				}
+			}
+
+			if got := gather(t, registry); strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("got metrics\n%v\nwant\n%v", strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestCollectorLabels(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+	}))
+	defer srv.Close()
+
+	collector := promscoro.NewCollector(promscoro.CollectorOpts{
+		Namespace:   "crm",
+		Buckets:     []float64{60},
+		ConstLabels: prometheus.Labels{"app": "billing"},
+	})
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+
+	credentials := scoro.Credentials{ApiKey: "key", CompanyID: "company", Subdomain: "test"}
+	client := scoro.NewClient(credentials).SetBaseURL(srv.URL + "/api").SetMetrics(collector)
+	if _, err := client.Invoices().List(context.Background(), nil, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "crm_request_duration_seconds" {
+			continue
+		}
+
+		metric := family.GetMetric()[0]
+
+		var labels []string
+		for _, label := range metric.GetLabel() {
+			labels = append(labels, label.GetName()+"="+label.GetValue())
+		}
+
+		want := "action=list app=billing company=company module=invoices subdomain=test"
+		if got := strings.Join(labels, " "); got != want {
+			t.Errorf("got labels %v, want %v", got, want)
+		}
+
+		buckets := metric.GetHistogram().GetBucket()
+		if len(buckets) != 1 || buckets[0].GetUpperBound() != 60 || buckets[0].GetCumulativeCount() != 1 {
+			t.Errorf("got buckets %v", buckets)
+		}
+		return
+	}
+
+	t.Error("latency histogram isn't collected")
+}
+
+// gather returns values of collected metrics formatted as name, status_code
+// label if any and value, sorted by name. Histograms are represented by
+// count of observations.
+func gather(t *testing.T, registry *prometheus.Registry) []string {
+	t.Helper()
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []string
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			name := family.GetName()
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "status_code" {
+					name += fmt.Sprintf("{status_code=%q}", label.GetValue())
+				}
+			}
+
+			switch {
+			case metric.GetCounter() != nil:
+				result = append(result, fmt.Sprintf("%v %v", name, metric.GetCounter().GetValue()))
+			case metric.GetHistogram() != nil:
+				result = append(result, fmt.Sprintf("%v_count %v", name, metric.GetHistogram().GetSampleCount()))
+			}
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -0,0 +1,224 @@
+package scoro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrNoCredentials is returned when credentials provider has no credentials
+// for the call, e.g. tenant isn't set in context or isn't known.
+var ErrNoCredentials = errors.New("scoro: no credentials")
+
+// CredentialsProvider resolves credentials for each API call. Providers make
+// it possible to serve several tenants by single client and to rotate API
+// keys without rebuilding services.
+//
+//		provider := scoro.NewTenantCredentials(map[string]scoro.Credentials{
+//			"acme": acmeCredentials,
+//		})
+//		client := scoro.NewClientWithProvider(provider)
+//
+//		ctx = scoro.WithTenant(ctx, "acme")
+//		products, err := client.Products().List(ctx, nil, 1, 10)
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticCredentials returns provider of fixed credentials.
+func StaticCredentials(credentials Credentials) CredentialsProvider {
+	return staticCredentials(credentials)
+}
+
+// EnvCredentials returns provider reading credentials from environment
+// variables {prefix}API_KEY, {prefix}COMPANY_ID and {prefix}SUBDOMAIN on each
+// call, prefix is "SCORO_" if empty. All variables must be set.
+func EnvCredentials(prefix string) CredentialsProvider {
+	if prefix == "" {
+		prefix = "SCORO_"
+	}
+
+	return envCredentials(prefix)
+}
+
+// FileCredentials provides credentials stored in JSON file with apiKey,
+// company_account_id and subdomain fields:
+//
+//		{"apiKey": "...", "company_account_id": "...", "subdomain": "company"}
+//
+// Other formats are supported by custom decoder, e.g. YAML:
+//
+//		provider := scoro.NewFileCredentials("scoro.yaml").SetDecoder(yaml.Unmarshal)
+//
+// All fields must be set. File is read again when its modification time
+// changes, so API key can be rotated by rewriting the file.
+type FileCredentials struct {
+	path   string
+	decode func(data []byte, v interface{}) error
+
+	mu          sync.Mutex
+	credentials Credentials
+	modTime     time.Time
+}
+
+// NewFileCredentials creates provider reading specified file.
+func NewFileCredentials(path string) *FileCredentials {
+	return &FileCredentials{path: path, decode: json.Unmarshal}
+}
+
+// SetDecoder sets function decoding content of the file, json.Unmarshal by
+// default. Decoder receives struct with json and yaml field tags.
+func (t *FileCredentials) SetDecoder(decode func(data []byte, v interface{}) error) *FileCredentials {
+	t.decode = decode
+	return t
+}
+
+// Credentials implements CredentialsProvider.
+func (t *FileCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if info.ModTime().Equal(t.modTime) {
+		return t.credentials, nil
+	}
+
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var file credentialsFile
+	if err := t.decode(data, &file); err != nil {
+		return Credentials{}, fmt.Errorf("scoro: read credentials %v: %w", t.path, err)
+	}
+
+	credentials := Credentials{ApiKey: file.ApiKey, CompanyID: file.CompanyID, Subdomain: file.Subdomain}
+	if name := emptyField(credentials, "apiKey", "company_account_id", "subdomain"); name != "" {
+		return Credentials{}, fmt.Errorf("%w: %v isn't set in %v", ErrNoCredentials, name, t.path)
+	}
+
+	t.credentials = credentials
+	t.modTime = info.ModTime()
+
+	return t.credentials, nil
+}
+
+// TenantCredentials provides credentials of tenant set in context by
+// WithTenant. Credentials can be added, replaced and removed at any time.
+type TenantCredentials struct {
+	mu      sync.RWMutex
+	tenants map[string]Credentials
+}
+
+// NewTenantCredentials creates provider with initial credentials of tenants.
+func NewTenantCredentials(tenants map[string]Credentials) *TenantCredentials {
+	t := &TenantCredentials{tenants: make(map[string]Credentials)}
+	for tenant, credentials := range tenants {
+		t.tenants[tenant] = credentials
+	}
+
+	return t
+}
+
+// Set adds or replaces credentials of tenant.
+func (t *TenantCredentials) Set(tenant string, credentials Credentials) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.tenants[tenant] = credentials
+}
+
+// Remove removes credentials of tenant.
+func (t *TenantCredentials) Remove(tenant string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.tenants, tenant)
+}
+
+// Credentials implements CredentialsProvider.
+func (t *TenantCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	tenant, ok := TenantFromContext(ctx)
+	if !ok {
+		return Credentials{}, fmt.Errorf("%w: tenant isn't set in context", ErrNoCredentials)
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	credentials, ok := t.tenants[tenant]
+	if !ok {
+		return Credentials{}, fmt.Errorf("%w: unknown tenant %q", ErrNoCredentials, tenant)
+	}
+
+	return credentials, nil
+}
+
+// WithTenant returns context carrying tenant id used by TenantCredentials.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns tenant id set by WithTenant.
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
+
+// Private
+
+type tenantKey struct{}
+
+type staticCredentials Credentials
+
+func (t staticCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	return Credentials(t), nil
+}
+
+type envCredentials string
+
+func (t envCredentials) Credentials(ctx context.Context) (Credentials, error) {
+	prefix := string(t)
+
+	credentials := Credentials{
+		ApiKey:    os.Getenv(prefix + "API_KEY"),
+		CompanyID: os.Getenv(prefix + "COMPANY_ID"),
+		Subdomain: os.Getenv(prefix + "SUBDOMAIN"),
+	}
+
+	if name := emptyField(credentials, prefix+"API_KEY", prefix+"COMPANY_ID", prefix+"SUBDOMAIN"); name != "" {
+		return Credentials{}, fmt.Errorf("%w: %v isn't set", ErrNoCredentials, name)
+	}
+
+	return credentials, nil
+}
+
+// emptyField returns name of the first empty field of credentials, names are
+// given in order of ApiKey, CompanyID and Subdomain fields.
+func emptyField(credentials Credentials, apiKey string, companyID string, subdomain string) string {
+	switch {
+	case credentials.ApiKey == "":
+		return apiKey
+	case credentials.CompanyID == "":
+		return companyID
+	case credentials.Subdomain == "":
+		return subdomain
+	}
+
+	return ""
+}
+
+type credentialsFile struct {
+	ApiKey    string `json:"apiKey" yaml:"apiKey"`
+	CompanyID string `json:"company_account_id" yaml:"company_account_id"`
+	Subdomain string `json:"subdomain" yaml:"subdomain"`
+}
@@ -0,0 +1,190 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want scoro.Credentials
+		err  string
+	}{
+		{
+			name: "all variables are set",
+			env:  map[string]string{"TEST_API_KEY": "key", "TEST_COMPANY_ID": "company", "TEST_SUBDOMAIN": "test"},
+			want: testCredentials,
+		},
+		{
+			name: "API key is missing",
+			env:  map[string]string{"TEST_COMPANY_ID": "company"},
+			err:  "scoro: no credentials: TEST_API_KEY isn't set",
+		},
+		{
+			name: "company ID is missing",
+			env:  map[string]string{"TEST_API_KEY": "key"},
+			err:  "scoro: no credentials: TEST_COMPANY_ID isn't set",
+		},
+		{
+			name: "subdomain is missing",
+			env:  map[string]string{"TEST_API_KEY": "key", "TEST_COMPANY_ID": "company"},
+			err:  "scoro: no credentials: TEST_SUBDOMAIN isn't set",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, name := range []string{"TEST_API_KEY", "TEST_COMPANY_ID", "TEST_SUBDOMAIN"} {
+				t.Setenv(name, test.env[name])
+			}
+
+			credentials, err := scoro.EnvCredentials("TEST_").Credentials(context.Background())
+			if test.err != "" {
+				if !errors.Is(err, scoro.ErrNoCredentials) || err.Error() != test.err {
+					t.Fatalf("got error %v, want %v", err, test.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if credentials != test.want {
+				t.Errorf("got credentials %+v, want %+v", credentials, test.want)
+			}
+		})
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scoro.json")
+	provider := scoro.NewFileCredentials(path)
+
+	// Modification times are set explicitly, file systems may not tell apart
+	// writes made within the same second.
+	modTime := time.Now()
+
+	tests := []struct {
+		name    string
+		content string
+		want    scoro.Credentials
+		err     error
+	}{
+		{
+			name:    "file is read",
+			content: `{"apiKey": "key", "company_account_id": "company", "subdomain": "test"}`,
+			want:    testCredentials,
+		},
+		{
+			name:    "rotated key is read",
+			content: `{"apiKey": "rotated", "company_account_id": "company", "subdomain": "test"}`,
+			want:    scoro.Credentials{ApiKey: "rotated", CompanyID: "company", Subdomain: "test"},
+		},
+		{
+			name:    "empty field",
+			content: `{"apiKey": "", "company_account_id": "company", "subdomain": "test"}`,
+			err:     scoro.ErrNoCredentials,
+		},
+		{
+			name:    "missing field",
+			content: `{"apiKey": "key", "company_account_id": "company"}`,
+			err:     scoro.ErrNoCredentials,
+		},
+		{
+			name:    "fixed file is read",
+			content: `{"apiKey": "fixed", "company_account_id": "company", "subdomain": "test"}`,
+			want:    scoro.Credentials{ApiKey: "fixed", CompanyID: "company", Subdomain: "test"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := os.WriteFile(path, []byte(test.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			modTime = modTime.Add(time.Second)
+			if err := os.Chtimes(path, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+
+			credentials, err := provider.Credentials(context.Background())
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			if credentials != test.want {
+				t.Errorf("got credentials %+v, want %+v", credentials, test.want)
+			}
+		})
+	}
+}
+
+func TestTenantCredentials(t *testing.T) {
+	srv := newTestServer(t)
+
+	tenants := scoro.NewTenantCredentials(map[string]scoro.Credentials{
+		"acme":  testCredentials,
+		"other": {ApiKey: "other", CompanyID: "company", Subdomain: "test"},
+	})
+	client := srv.Client().SetCredentialsProvider(tenants)
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		update func()
+		err    error
+	}{
+		{
+			name: "tenant isn't set",
+			ctx:  context.Background(),
+			err:  scoro.ErrNoCredentials,
+		},
+		{
+			name: "unknown tenant",
+			ctx:  scoro.WithTenant(context.Background(), "unknown"),
+			err:  scoro.ErrNoCredentials,
+		},
+		{
+			name: "known tenant",
+			ctx:  scoro.WithTenant(context.Background(), "acme"),
+		},
+		{
+			name: "tenant with credentials rejected by Scoro",
+			ctx:  scoro.WithTenant(context.Background(), "other"),
+			err:  scoro.ErrUnauthorized,
+		},
+		{
+			name:   "replaced credentials",
+			ctx:    scoro.WithTenant(context.Background(), "other"),
+			update: func() { tenants.Set("other", testCredentials) },
+		},
+		{
+			name:   "removed tenant",
+			ctx:    scoro.WithTenant(context.Background(), "acme"),
+			update: func() { tenants.Remove("acme") },
+			err:    scoro.ErrNoCredentials,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.update != nil {
+				test.update()
+			}
+
+			if _, err := client.Products().List(test.ctx, nil, 1, 10); !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
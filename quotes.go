@@ -1,9 +1,5 @@
 package scoro
 
-import (
-	"errors"
-)
-
 // QuoteLine struct represents quote lines data type of Scoro API.
 // https://api.scoro.com/api/#quoteLinesApiDocs
 type QuoteLine struct {
@@ -57,83 +53,40 @@ type Quote struct {
 }
 type QuoteList []Quote
 
-// QuotesAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of quotes API
-type QuotesAPI struct {
-	credentials Credentials
-}
-
-func Quotes(credentials Credentials) QuotesAPI {
-	return QuotesAPI{credentials}
-}
-
-func (t QuotesAPI) View(id string) (*Quote, error) {
-	resp, err := t.Request().SetResponse(quoteResponse{}).View(id)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*quoteResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
+// GetID returns ID of the quote, zero for new quotes.
+func (t *Quote) GetID() int {
+	if t.Id == nil {
+		return 0
 	}
 
-	return &result.Quote, nil
+	return *t.Id
 }
 
-func (t QuotesAPI) List(filter interface{}, page int, count int) (*QuoteList, error) {
-	resp, err := t.Request().SetResponse(quoteListResponse{}).List(filter, page, count)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*quoteListResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Quotes, nil
+// SetID sets ID of the quote.
+func (t *Quote) SetID(id int) {
+	t.Id = &id
 }
 
-func (t QuotesAPI) Modify(product Quote) (*Quote, error) {
-	resp, err := t.Request().SetResponse(quoteResponse{}).Modify(product)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*quoteResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Quote, nil
+// GetModifiedDate returns last modification time of the quote.
+func (t *Quote) GetModifiedDate() Time {
+	return t.ModifiedDate
 }
 
-func (t QuotesAPI) Delete(id int) error {
-	_, err := t.Request().SetResponse(quoteResponse{}).Delete(id, nil)
-	return err
+// GetDeletedDate returns deletion time of the quote.
+func (t *Quote) GetDeletedDate() Time {
+	return t.DeletedDate
 }
 
-func (t QuotesAPI) Request() Request {
-	return NewRequest(t.credentials, "quotes")
-}
-
-// Private
-
-type quoteResponse struct {
-	ResponseHeader `json:",inline"`
-	Quote          Quote `json:"data,omitempty"`
+// Deleted reports whether the quote is deleted.
+func (t *Quote) Deleted() bool {
+	return t.IsDeleted.Value
 }
 
-type quoteListResponse struct {
-	ResponseHeader `json:",inline"`
-	Quotes         QuoteList `json:"data,omitempty"`
-}
-
-func (t quoteResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
-}
+// QuotesAPI provides type safe wrappers for View/List/Modify/Delete actions
+// of quotes API
+type QuotesAPI = Service[Quote]
 
-func (t quoteListResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+// Quotes is shortcut for NewClient(credentials).Quotes().
+func Quotes(credentials Credentials) QuotesAPI {
+	return NewClient(credentials).Quotes()
 }
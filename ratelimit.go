@@ -0,0 +1,97 @@
+package scoro
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is token bucket limiter of API requests. Separate bucket is
+// kept for each Scoro account (subdomain and company), so single limiter can
+// be shared by clients of different tenants. Every attempt of a request,
+// including retries, takes a token.
+//
+// Example limiting each account to 2 requests per second with bursts of 10:
+//
+//		limiter := scoro.NewRateLimiter(2, 10)
+//		client := scoro.NewClient(credentials).SetRateLimiter(limiter)
+type RateLimiter struct {
+	rate  float64
+	burst int
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter creates limiter refilling rate tokens per second up to burst
+// tokens per account.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Wait blocks until token of specified account is available or context is done.
+func (t *RateLimiter) Wait(ctx context.Context, subdomain string, companyID string) error {
+	if t.rate <= 0 {
+		return nil
+	}
+
+	key := subdomain + "/" + companyID
+
+	t.mu.Lock()
+	b, ok := t.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(t.burst), updated: time.Now()}
+		t.buckets[key] = b
+	}
+	delay := b.take(t.rate, t.burst)
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		// Return reserved token, so it isn't lost for other callers.
+		t.mu.Lock()
+		b.tokens++
+		t.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// Private
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take refills bucket and takes a token from it. Token is reserved even if
+// bucket is empty, and delay until the token is refilled is returned, so
+// concurrent waiters are served in order.
+func (t *bucket) take(rate float64, burst int) time.Duration {
+	now := time.Now()
+	t.tokens += now.Sub(t.updated).Seconds() * rate
+	if t.tokens > float64(burst) {
+		t.tokens = float64(burst)
+	}
+	t.updated = now
+
+	t.tokens--
+	if t.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-t.tokens / rate * float64(time.Second))
+}
@@ -0,0 +1,117 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     float64
+		burst    int
+		requests int
+		timeout  time.Duration
+		minWait  time.Duration
+		err      error
+	}{
+		{
+			name:     "burst isn't delayed",
+			rate:     1,
+			burst:    3,
+			requests: 3,
+		},
+		{
+			name:     "requests over burst wait",
+			rate:     20,
+			burst:    1,
+			requests: 3,
+			minWait:  90 * time.Millisecond,
+		},
+		{
+			name:     "zero rate disables limiter",
+			burst:    1,
+			requests: 5,
+		},
+		{
+			name:     "wait is canceled",
+			rate:     0.1,
+			burst:    1,
+			requests: 2,
+			timeout:  20 * time.Millisecond,
+			err:      context.DeadlineExceeded,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limiter := scoro.NewRateLimiter(test.rate, test.burst)
+
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			var err error
+			for i := 0; i < test.requests && err == nil; i++ {
+				err = limiter.Wait(ctx, "test", "company")
+			}
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			if elapsed < test.minWait {
+				t.Errorf("waited %v, want at least %v", elapsed, test.minWait)
+			}
+
+			if test.err != nil && elapsed > time.Second {
+				t.Errorf("canceled wait took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestRateLimiterSeparatesAccounts(t *testing.T) {
+	limiter := scoro.NewRateLimiter(0.1, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	for _, companyID := range []string{"a", "b", "c"} {
+		if err := limiter.Wait(ctx, "test", companyID); err != nil {
+			t.Fatalf("company %v: %v", companyID, err)
+		}
+	}
+}
+
+func TestRateLimiterClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+	}))
+	defer srv.Close()
+
+	products := scoro.NewClient(testCredentials).SetBaseURL(srv.URL + "/api").SetRateLimiter(scoro.NewRateLimiter(0.1, 1)).Products()
+
+	if _, err := products.List(context.Background(), nil, 1, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := products.List(ctx, nil, 1, 10); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v, want deadline exceeded", err)
+	}
+}
@@ -1,9 +1,5 @@
 package scoro
 
-import (
-	"errors"
-)
-
 // Receipt struct represents receipts data type of Scoro API.
 // https://api.scoro.com/api/#receiptsApiDocs
 type Receipt struct {
@@ -18,84 +14,25 @@ type Receipt struct {
 }
 type ReceiptList []Receipt
 
-// ReceiptsAPI provides type safe wrappers for View/List/Modify/Delete actions
-// of receipts API
-type ReceiptsAPI struct {
-	credentials Credentials
-}
-
-func Receipts(credentials Credentials) ReceiptsAPI {
-	return ReceiptsAPI{credentials}
-}
-
-func (t ReceiptsAPI) View(id string) (*Receipt, error) {
-	resp, err := t.Request().SetResponse(receiptResponse{}).View(id)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*receiptResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Receipt, nil
-}
-
-func (t ReceiptsAPI) List(filter interface{}, page int, count int) (*ReceiptList, error) {
-	resp, err := t.Request().SetResponse(receiptListResponse{}).List(filter, page, count)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*receiptListResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
-	}
-
-	return &result.Receipts, nil
-}
-
-func (t ReceiptsAPI) Modify(receipt Receipt) (*Receipt, error) {
-	resp, err := t.Request().SetResponse(receiptResponse{}).Modify(receipt)
-	if err != nil {
-		return nil, err
-	}
-
-	result, ok := resp.(*receiptResponse)
-	if !ok {
-		return nil, errors.New("Invalid response format")
+// GetID returns ID of the receipt, zero for new receipts.
+func (t *Receipt) GetID() int {
+	if t.Id == nil {
+		return 0
 	}
 
-	return &result.Receipt, nil
+	return *t.Id
 }
 
-func (t ReceiptsAPI) Delete(id int) error {
-	_, err := t.Request().SetResponse(receiptResponse{}).Delete(id, nil)
-
-	return err
-}
-
-func (t ReceiptsAPI) Request() Request {
-	return NewRequest(t.credentials, "receipts")
+// SetID sets ID of the receipt.
+func (t *Receipt) SetID(id int) {
+	t.Id = &id
 }
 
-// Private
-
-type receiptResponse struct {
-	ResponseHeader `json:",inline"`
-	Receipt        Receipt `json:"data,omitempty"`
-}
-
-type receiptListResponse struct {
-	ResponseHeader `json:",inline"`
-	Receipts       ReceiptList `json:"data,omitempty"`
-}
-
-func (t receiptResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
-}
+// ReceiptsAPI provides type safe wrappers for View/List/Modify/Delete actions
+// of receipts API
+type ReceiptsAPI = Service[Receipt]
 
-func (t receiptListResponse) GetResponseHeader() ResponseHeader {
-	return t.ResponseHeader
+// Receipts is shortcut for NewClient(credentials).Receipts().
+func Receipts(credentials Credentials) ReceiptsAPI {
+	return NewClient(credentials).Receipts()
 }
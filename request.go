@@ -1,13 +1,11 @@
 package scoro
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strconv"
-	"strings"
-
-	resty "gopkg.in/resty.v1"
 )
 
 // Request helps to build and send custom request to Scoro API. It supports
@@ -19,12 +17,12 @@ import (
 //
 // Example:
 //
-// response := NewRequest(credentials).SetResponse(ProductResponse{}).View(id)
+// response := NewRequest(client, "products").SetResponse(ProductResponse{}).View(ctx, id)
 type Request struct {
-	credentials Credentials
-	lang        string
-	respType    ResponseType
-	entityType  string
+	client     *Client
+	lang       string
+	respType   ResponseType
+	entityType string
 }
 
 // ResponseHeader represents base part of API response that is common for
@@ -51,26 +49,40 @@ type ResponseType interface {
 	GetResponseHeader() ResponseHeader
 }
 
-// NewRequest creates request object configured with specified credentials and
+// GetResponseHeader implements ResponseType, so header alone can be used as
+// response type when response data isn't needed.
+func (t ResponseHeader) GetResponseHeader() ResponseHeader {
+	return t
+}
+
+// NewRequest creates request object sent by specified client and
 // pointed to the endpoint corresponding to the specified entityType.
 //
 // entityType can be "products", "orders", "invoices" or any other type supported
 // by Scoro API
-func NewRequest(credentials Credentials, entityType string) Request {
+func NewRequest(client *Client, entityType string) Request {
 	return Request{
-		credentials: credentials,
-		lang:        DefaultLang,
-		entityType:  entityType,
+		client:     client,
+		lang:       client.lang,
+		entityType: entityType,
 	}
 }
 
+// SetLang method sets language of the request, language of the client is used
+// by default. Localized fields received as single string are stored under
+// this language.
+func (t Request) SetLang(lang string) Request {
+	t.lang = lang
+	return t
+}
+
 // SetResponse method is to register the response object for automatic unmarshalling
 // of JSON responses. Response type shoul conforms to the ResponseType interface.
 //
 //		request.SetResult(ProductResponse{})
 //
 // Accessing a result value
-//		if resp, err := request.View(id); err != nil {
+//		if resp, err := request.View(ctx, id); err == nil {
 //			product := resp.(*productResponse).Product
 //		}
 func (t Request) SetResponse(response ResponseType) Request {
@@ -79,100 +91,120 @@ func (t Request) SetResponse(response ResponseType) Request {
 }
 
 // View method sends "view" action request
-func (t Request) View(id string) (interface{}, error) {
-	url := makeUrl(t.credentials.Subdomain, t.entityType, "view", id)
-	body := requestBody{Credentials: t.credentials, Lang: t.lang}
+func (t Request) View(ctx context.Context, id string) (interface{}, error) {
+	body := requestBody{Lang: t.lang}
 
-	return sendRequest(url, body, t.respType)
+	return t.send(ctx, "view", id, body)
 }
 
 // List method sends "list" action request
-func (t Request) List(filter interface{}, page int, count int) (interface{}, error) {
-	url := makeUrl(t.credentials.Subdomain, t.entityType, "list")
+func (t Request) List(ctx context.Context, filter interface{}, page int, count int) (interface{}, error) {
 	body := requestBody{
-		Credentials: t.credentials,
-		Lang:        t.lang,
-		Filter:      filter,
-		Page:        page,
-		PerPage:     count,
+		Lang:    t.lang,
+		Filter:  filterBody(filter),
+		Page:    page,
+		PerPage: count,
 	}
 
-	return sendRequest(url, body, t.respType)
+	return t.send(ctx, "list", "", body)
 }
 
 // Modify method sends "modify" action request
-func (t Request) Modify(obj interface{}) (interface{}, error) {
-	url := makeUrl(t.credentials.Subdomain, t.entityType, "modify")
-	body := requestBody{Credentials: t.credentials, Lang: t.lang, Request: obj}
+func (t Request) Modify(ctx context.Context, obj interface{}) (interface{}, error) {
+	body := requestBody{Lang: t.lang, Request: obj}
 
-	return sendRequest(url, body, t.respType)
+	return t.send(ctx, "modify", "", body)
 }
 
 // Delete method sends "delete" action request
-func (t Request) Delete(id int, filter interface{}) (interface{}, error) {
-	url := makeUrl(t.credentials.Subdomain, t.entityType, "delete", strconv.Itoa(id))
-	body := requestBody{Credentials: t.credentials, Lang: t.lang, Request: filter}
+func (t Request) Delete(ctx context.Context, id int, filter interface{}) (interface{}, error) {
+	body := requestBody{Lang: t.lang, Request: filterBody(filter)}
 
-	return sendRequest(url, body, t.respType)
+	return t.send(ctx, "delete", strconv.Itoa(id), body)
 }
 
 // Private
 
 type requestBody struct {
-	Credentials `json:",inline"`
-	Lang        string      `json:"lang"`
-	Page        int         `json:"page"`
-	PerPage     int         `json:"per_page"`
-	Request     interface{} `json:"request,omitempty"`
-	Filter      interface{} `json:"filter,omitempty"`
+	Lang    string      `json:"lang"`
+	Page    int         `json:"page"`
+	PerPage int         `json:"per_page"`
+	Request interface{} `json:"request,omitempty"`
+	Filter  interface{} `json:"filter,omitempty"`
 }
 
-func makeUrl(subdomain string, entityType string, action string, params ...string) string {
-	baseURL := fmt.Sprintf("https://%v.scoro.com/api/v1", subdomain)
-
-	urlParts := []string{baseURL, entityType, action}
-	urlParts = append(urlParts, params...)
+func (t Request) send(ctx context.Context, action string, id string, body requestBody) (interface{}, error) {
+	envelope := envelopeFor(t.client.version)
 
-	return strings.Join(urlParts, "/")
-}
+	data, err := envelope.encodeRequest(body)
+	if err != nil {
+		return nil, err
+	}
 
-func sendRequest(url string, body interface{}, respType ResponseType) (interface{}, error) {
-	req := resty.R()
-	req = req.ExpectContentType("application/json")
-	req = req.SetResult(respType)
+	credentials, err := t.client.credentials.Credentials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("scoro: resolve credentials of %v/%v: %w", t.entityType, action, err)
+	}
 
-	if body != nil {
-		req = req.SetBody(body)
+	call := &Call{
+		Module:      t.entityType,
+		Action:      action,
+		ID:          id,
+		Subdomain:   credentials.Subdomain,
+		CompanyID:   credentials.CompanyID,
+		Page:        body.Page,
+		PerPage:     body.PerPage,
+		Body:        data,
+		Header:      t.client.header(),
+		credentials: credentials,
 	}
 
-	resp, err := req.Post(url)
+	resp, err := t.client.handler()(ctx, call)
 	if err != nil {
 		return nil, err
 	}
 
-	return unmarshalResponse(resp)
+	return t.unmarshalResponse(call, resp, envelope)
 }
 
-func unmarshalResponse(restyResp *resty.Response) (interface{}, error) {
-	if restyResp.StatusCode() != http.StatusOK {
-		return nil, errors.New("Error status: " + restyResp.Status())
+func (t Request) unmarshalResponse(call *Call, resp *Response, envelope envelope) (interface{}, error) {
+	if resp == nil {
+		return nil, invalidResponse(call.Module, call.Action, resp)
 	}
 
-	response, validFormat := restyResp.Result().(ResponseType)
+	response, validFormat := newResponse(t.respType)
 	if !validFormat {
-		return nil, errors.New("Invalid format")
+		return nil, invalidResponse(call.Module, call.Action, t.respType)
 	}
 
+	if err := envelope.decodeResponse(resp.Body, response); err != nil {
+		return nil, &DecodeError{Module: call.Module, Action: call.Action, Err: err}
+	}
+
+	// Responses returned by middleware bypass transport checks, so status
+	// is checked again here.
 	header := response.GetResponseHeader()
 
-	if header.Status == "OK" {
+	if resp.HTTPStatus == http.StatusOK && header.Status == "OK" {
+		localizeStrings(reflect.ValueOf(response), t.lang)
 		return response, nil
 	}
 
-	if header.Messages != nil {
-		errStr := strings.Join(header.Messages.Error, "; ")
-		return nil, errors.New(errStr)
+	return nil, newAPIError(call, resp)
+}
+
+// newResponse allocates pointer to a new value of the registered response
+// type, so SetResponse(productResponse{}) results in *productResponse.
+func newResponse(respType ResponseType) (ResponseType, bool) {
+	if respType == nil {
+		return &ResponseHeader{}, true
+	}
+
+	typ := reflect.TypeOf(respType)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
 	}
 
-	return nil, errors.New("Error: " + header.StatusCode)
+	response, ok := reflect.New(typ).Interface().(ResponseType)
+	return response, ok
 }
@@ -0,0 +1,149 @@
+package scoro
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests. Transport
+// failures, 429 Too Many Requests and 5xx responses are retried with
+// exponential backoff. Delay requested by Retry-After header of 429/503
+// responses takes precedence over computed backoff, but requests asking for
+// longer delay than MaxBackoff aren't retried, their error is returned
+// instead.
+//
+// Only idempotent view and list actions are retried unless RetryModify is set.
+// Zero value disables retries.
+//
+// Example:
+//
+//		client.SetRetryPolicy(scoro.DefaultRetryPolicy)
+type RetryPolicy struct {
+	// MaxAttempts is maximum number of attempts including the first one.
+	MaxAttempts int
+
+	// MinBackoff is delay before the second attempt, it is doubled for every
+	// next attempt.
+	MinBackoff time.Duration
+
+	// MaxBackoff limits delay between attempts, including delays requested
+	// by Retry-After header.
+	MaxBackoff time.Duration
+
+	// Jitter is fraction of the delay, from 0 to 1, which is randomized to
+	// spread retries of concurrent requests.
+	Jitter float64
+
+	// RetryModify enables retries of non-idempotent modify and delete actions.
+	RetryModify bool
+}
+
+// DefaultRetryPolicy is reasonable retry policy for background jobs.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// Private
+
+func (t RetryPolicy) allows(action string) bool {
+	if t.MaxAttempts <= 1 {
+		return false
+	}
+
+	return t.RetryModify || action == "view" || action == "list"
+}
+
+// backoff returns delay before the next attempt. It reports false when
+// Retry-After asks for longer delay than MaxBackoff.
+func (t RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		if t.MaxBackoff > 0 && apiErr.RetryAfter > t.MaxBackoff {
+			return 0, false
+		}
+
+		return apiErr.RetryAfter, true
+	}
+
+	delay := t.MinBackoff
+	for i := 1; i < attempt && (t.MaxBackoff <= 0 || delay < t.MaxBackoff); i++ {
+		delay *= 2
+	}
+
+	if t.MaxBackoff > 0 && delay > t.MaxBackoff {
+		delay = t.MaxBackoff
+	}
+
+	if t.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * t.Jitter * float64(delay))
+	}
+
+	return delay, true
+}
+
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer)
+}
+
+// parseRetryAfter parses Retry-After header, which holds either number of
+// seconds or HTTP date.
+func parseRetryAfter(header http.Header) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func setAttempts(err error, attempts int) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.Attempts = attempts
+	}
+
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		transportErr.Attempts = attempts
+	}
+
+	return err
+}
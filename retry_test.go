@@ -0,0 +1,138 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	policy := scoro.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second}
+
+	tests := []struct {
+		name       string
+		policy     scoro.RetryPolicy
+		status     int
+		retryAfter int
+		failures   int32
+		modify     bool
+		attempts   int32
+		err        error
+	}{
+		{
+			name:     "server error is retried",
+			policy:   policy,
+			status:   http.StatusServiceUnavailable,
+			failures: 2,
+			attempts: 3,
+		},
+		{
+			name:     "attempts are exhausted",
+			policy:   policy,
+			status:   http.StatusInternalServerError,
+			failures: 5,
+			attempts: 3,
+			err:      scoro.ErrServer,
+		},
+		{
+			name:     "zero policy doesn't retry",
+			status:   http.StatusServiceUnavailable,
+			failures: 1,
+			attempts: 1,
+			err:      scoro.ErrServer,
+		},
+		{
+			name:     "modify isn't retried",
+			policy:   policy,
+			status:   http.StatusServiceUnavailable,
+			failures: 1,
+			modify:   true,
+			attempts: 1,
+			err:      scoro.ErrServer,
+		},
+		{
+			name:     "modify is retried if enabled",
+			policy:   scoro.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, RetryModify: true},
+			status:   http.StatusServiceUnavailable,
+			failures: 1,
+			modify:   true,
+			attempts: 2,
+		},
+		{
+			name:     "validation error isn't retried",
+			policy:   policy,
+			status:   http.StatusBadRequest,
+			failures: 1,
+			attempts: 1,
+			err:      scoro.ErrValidation,
+		},
+		{
+			name:       "retry after within max backoff",
+			policy:     policy,
+			status:     http.StatusTooManyRequests,
+			retryAfter: 1,
+			failures:   1,
+			attempts:   2,
+		},
+		{
+			name:       "retry after above max backoff",
+			policy:     policy,
+			status:     http.StatusTooManyRequests,
+			retryAfter: 60,
+			failures:   1,
+			attempts:   1,
+			err:        scoro.ErrRateLimited,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) > test.failures {
+					fmt.Fprint(w, `{"status":"OK","statusCode":"200","data":[]}`)
+					return
+				}
+
+				if test.retryAfter > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(test.retryAfter))
+				}
+				w.WriteHeader(test.status)
+				fmt.Fprintf(w, `{"status":"ERROR","statusCode":"%d","messages":{"error":["%v"]}}`, test.status, http.StatusText(test.status))
+			}))
+			defer srv.Close()
+
+			client := scoro.NewClient(testCredentials).SetBaseURL(srv.URL + "/api").SetRetryPolicy(test.policy)
+			request := scoro.NewRequest(client, "products")
+
+			var err error
+			if test.modify {
+				id := 1
+				_, err = request.Modify(context.Background(), scoro.Product{Id: &id, Name: "B"})
+			} else {
+				_, err = request.List(context.Background(), nil, 1, 10)
+			}
+
+			if test.err == nil && err != nil || !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			if got := attempts.Load(); got != test.attempts {
+				t.Errorf("got %d attempts, want %d", got, test.attempts)
+			}
+
+			var apiErr *scoro.APIError
+			if errors.As(err, &apiErr) && apiErr.Attempts != int(test.attempts) {
+				t.Errorf("error reports %d attempts, want %d", apiErr.Attempts, test.attempts)
+			}
+		})
+	}
+}
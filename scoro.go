@@ -7,20 +7,34 @@
 // View/List/Modify/Delete actions which are directly mapped to the
 // corresponding API calls.
 //
+// Services are bound to Client, which holds credentials and HTTP settings:
+//
+//    client := scoro.NewClient(credentials)
+//
 // Products service:
 //
-//    products := scoro.Products(credentials)
+//    products := client.Products()
 //
 // Quotes service:
 //
-//    quotes := scoro.Quotes(credentials)
+//    quotes := client.Quotes()
 //
 // Orders service:
 //
-//    orders := scoro.Orders(credentials)
+//    orders := client.Orders()
 //
 // Invoices service:
 //
-//    invoices := scoro.Invoices(credentials)
+//    invoices := client.Invoices()
+//
+// Modules that aren't implemented by the library yet can be accessed with
+// generic service:
+//
+//    projects := scoro.NewService[Project](client, "projects")
+//
+// Every action accepts context.Context, which is used for request-scoped
+// deadlines and cancellation:
+//
+//    product, err := products.View(ctx, "1")
 //
 package scoro
@@ -0,0 +1,57 @@
+package scoro_test
+
+import (
+	"sort"
+	"sync"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/scorotest"
+)
+
+var testCredentials = scoro.Credentials{ApiKey: "key", CompanyID: "company", Subdomain: "test"}
+
+// newTestServer starts fake Scoro API, which is closed at the end of test.
+func newTestServer(t *testing.T) *scorotest.Server {
+	t.Helper()
+
+	srv := scorotest.NewServer(testCredentials)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// captureModify registers hook collecting fields sent by modify requests.
+func captureModify(srv *scorotest.Server) func() []map[string]interface{} {
+	var mu sync.Mutex
+	var requests []map[string]interface{}
+
+	srv.AddHook(func(req *scorotest.Request) *scorotest.Fault {
+		if req.Action == "modify" {
+			fields, _ := req.Body["request"].(map[string]interface{})
+
+			mu.Lock()
+			requests = append(requests, fields)
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	return func() []map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return append([]map[string]interface{}{}, requests...)
+	}
+}
+
+// fieldNames returns sorted names of fields.
+func fieldNames(fields map[string]interface{}) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
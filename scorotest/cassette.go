@@ -0,0 +1,281 @@
+package scorotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+// ErrUnmatched is returned by replaying recorder for requests which have no
+// recorded interaction.
+var ErrUnmatched = errors.New("scorotest: no recorded interaction matches request")
+
+// Interaction is recorded request/response pair of Scoro API call. Request
+// and response bodies are stored with credentials scrubbed.
+type Interaction struct {
+	Module       string          `json:"module"`
+	Action       string          `json:"action"`
+	ID           string          `json:"id,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body"`
+	Status       int             `json:"status"`
+	Header       http.Header     `json:"header,omitempty"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+
+	// ResponseText holds response body which isn't valid JSON, e.g. error
+	// page of a proxy.
+	ResponseText string `json:"response_text,omitempty"`
+}
+
+// Cassette is content of cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is http.RoundTripper recording Scoro API interactions to cassette
+// file or replaying them from it. Recorder is set as client transport:
+//
+//		// Record once against real account
+//		recorder := scorotest.NewRecorder("testdata/products.json", nil)
+//		client := scoro.NewClient(credentials).SetTransport(recorder)
+//		...
+//		err := recorder.Save()
+//
+//		// Replay in CI
+//		recorder, err := scorotest.NewReplayer("testdata/products.json")
+//		client := scoro.NewClient(credentials).SetTransport(recorder)
+//
+// Replayed requests are matched by module, action, id and normalized body
+// (credentials scrubbed, keys sorted). Each interaction is replayed once, in
+// recorded order. Unmatched requests fail with ErrUnmatched.
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+	replay    bool
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder creates recorder sending requests with transport (or
+// http.DefaultTransport if nil) and recording interactions. Recorded
+// cassette is written by Save.
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{path: path, transport: transport}
+}
+
+// NewReplayer creates recorder replaying interactions of cassette file.
+func NewReplayer(path string) (*Recorder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Recorder{path: path, replay: true}
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return nil, fmt.Errorf("scorotest: invalid cassette %v: %w", path, err)
+	}
+	t.used = make([]bool, len(t.cassette.Interactions))
+
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	module, action, id, ok := parseURL(req.URL.Path)
+	if !ok {
+		return nil, fmt.Errorf("scorotest: unexpected request %v", req.URL.Path)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	normalized := normalizeBody(body)
+
+	if t.replay {
+		return t.replayInteraction(req, module, action, id, normalized)
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Module:      module,
+		Action:      action,
+		ID:          id,
+		RequestBody: normalized,
+		Status:      resp.StatusCode,
+		Header:      recordedHeader(resp.Header),
+	}
+
+	if json.Valid(respBody) {
+		interaction.ResponseBody = normalizeBody(respBody)
+	} else {
+		interaction.ResponseText = string(respBody)
+	}
+
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, interaction)
+	t.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// Save writes recorded interactions to cassette file.
+func (t *Recorder) Save() error {
+	t.mu.Lock()
+	data, err := json.MarshalIndent(t.cassette, "", "\t")
+	t.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.path, data, 0644)
+}
+
+// Unused returns replayed interactions which haven't been requested, it
+// helps to detect that tested code stopped sending some requests.
+func (t *Recorder) Unused() []Interaction {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var result []Interaction
+	for i, used := range t.used {
+		if !used {
+			result = append(result, t.cassette.Interactions[i])
+		}
+	}
+
+	return result
+}
+
+// Private
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+func (t *Recorder) replayInteraction(req *http.Request, module, action, id string, body []byte) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || interaction.Module != module || interaction.Action != action || interaction.ID != id {
+			continue
+		}
+
+		if !bytes.Equal(normalizeBody(interaction.RequestBody), body) {
+			continue
+		}
+
+		t.used[i] = true
+
+		header := make(http.Header)
+		for key, values := range interaction.Header {
+			header[key] = values
+		}
+
+		respBody := []byte(interaction.ResponseBody)
+		if respBody == nil {
+			respBody = []byte(interaction.ResponseText)
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %v", interaction.Status, http.StatusText(interaction.Status)),
+			StatusCode:    interaction.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(respBody)),
+			ContentLength: int64(len(respBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v/%v %v in %v: %s", ErrUnmatched, module, action, id, t.path, body)
+}
+
+// parseURL extracts module, action and id from path of any API version and
+// base path, e.g. /api/v1/invoices/prepayments/view/1.
+func parseURL(path string) (string, string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range parts {
+		if !versionSegment.MatchString(part) {
+			continue
+		}
+
+		req, ok := parsePath("/api/v1/" + strings.Join(parts[i+1:], "/"))
+		if !ok {
+			return "", "", "", false
+		}
+
+		return req.Module, req.Action, req.ID, true
+	}
+
+	return "", "", "", false
+}
+
+// normalizeBody scrubs credentials and re-encodes JSON body with sorted keys
+// and without whitespace, so bodies can be compared byte by byte.
+func normalizeBody(body []byte) []byte {
+	body = scoro.RedactBody(body)
+
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return body
+	}
+
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return body
+	}
+
+	return normalized
+}
+
+func recordedHeader(header http.Header) http.Header {
+	result := make(http.Header)
+	for _, key := range []string{"Content-Type", "Retry-After"} {
+		if value := header.Get(key); value != "" {
+			result.Set(key, value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,103 @@
+package scorotest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassettes", "products.json")
+
+	srv := NewServer(testCredentials)
+	defer srv.Close()
+
+	recorder := NewRecorder(path, nil)
+	products := srv.Client().SetTransport(recorder).Products()
+
+	created, err := products.Modify(ctx, scoro.Product{Code: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := products.View(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), testCredentials.ApiKey) {
+		t.Error("cassette contains API key")
+	}
+
+	replayer, err := NewReplayer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := scoro.NewClient(testCredentials).SetBaseURL("http://scoro.invalid/api").SetTransport(replayer).Products()
+
+	tests := []struct {
+		name string
+		call func() error
+		err  error
+	}{
+		{
+			name: "recorded modify",
+			call: func() error {
+				product, err := replayed.Modify(ctx, scoro.Product{Code: "A"})
+				if err == nil && *product.Id != *created.Id {
+					t.Errorf("got ID %d, want %d", *product.Id, *created.Id)
+				}
+				return err
+			},
+		},
+		{
+			name: "recorded view",
+			call: func() error {
+				_, err := replayed.View(ctx, "1")
+				return err
+			},
+		},
+		{
+			name: "interaction is replayed once",
+			call: func() error {
+				_, err := replayed.View(ctx, "1")
+				return err
+			},
+			err: ErrUnmatched,
+		},
+		{
+			name: "unrecorded request",
+			call: func() error {
+				_, err := replayed.Modify(ctx, scoro.Product{Code: "B"})
+				return err
+			},
+			err: ErrUnmatched,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.call(); !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		})
+	}
+
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("got %d unused interactions", len(unused))
+	}
+}
@@ -0,0 +1,211 @@
+package scorotest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+// MemoryService is in-memory implementation of scoro.EntityService, which
+// stores records in a map. It follows the same rules as Server: IDs are
+// assigned on create, modify merges fields into stored record, modified_date
+// is stamped, delete is soft and list supports the same filters.
+//
+// Example:
+//
+//		products := scorotest.NewMemoryService[scoro.Product]("products")
+//		err := syncProducts(ctx, products) // accepts scoro.ProductsService
+type MemoryService[T any, PT scoro.Entity[T]] struct {
+	module string
+
+	mu      sync.Mutex
+	records map[int]Record
+	lastID  int
+	now     func() time.Time
+}
+
+// NewMemoryService creates empty service of specified module, module name is
+// used in returned errors.
+func NewMemoryService[T any, PT scoro.Entity[T]](module string) *MemoryService[T, PT] {
+	return &MemoryService[T, PT]{
+		module:  module,
+		records: make(map[int]Record),
+		now:     time.Now,
+	}
+}
+
+// SetNow overrides clock used to stamp modified_date and deleted_date.
+func (t *MemoryService[T, PT]) SetNow(now func() time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.now = now
+}
+
+func (t *MemoryService[T, PT]) View(ctx context.Context, id string) (*T, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	numID, _ := strconv.Atoi(id)
+	rec, ok := t.records[numID]
+	if !ok {
+		return nil, t.notFound("view", id)
+	}
+
+	return t.decode(numID, rec)
+}
+
+func (t *MemoryService[T, PT]) List(ctx context.Context, filter scoro.Filter, page int, count int) ([]T, error) {
+	var filterValues map[string]interface{}
+	if filter != nil {
+		filterValues = filter.FilterValues()
+	}
+
+	filterRec, err := toRecord(filterValues)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ids := make([]int, 0, len(t.records))
+	for id, rec := range t.records {
+		if matchFilter(rec, filterRec) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	if page < 1 {
+		page = 1
+	}
+
+	if count < 1 {
+		count = DefaultPerPage
+	}
+
+	result := []T{}
+	for i := (page - 1) * count; i < len(ids) && i < page*count; i++ {
+		item, err := t.decode(ids[i], t.records[ids[i]])
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *item)
+	}
+
+	return result, nil
+}
+
+func (t *MemoryService[T, PT]) Modify(ctx context.Context, obj T) (*T, error) {
+	changes, err := toRecord(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.modify(PT(&obj).GetID(), changes)
+}
+
+func (t *MemoryService[T, PT]) ModifyFields(ctx context.Context, fields map[string]interface{}) (*T, error) {
+	changes, err := toRecord(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	// ID is read by decoding fields into the record type, which knows name
+	// of its ID field.
+	var obj T
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	return t.modify(PT(&obj).GetID(), changes)
+}
+
+func (t *MemoryService[T, PT]) Delete(ctx context.Context, id int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rec, ok := t.records[id]
+	if !ok || str(rec["is_deleted"]) == "1" {
+		return t.notFound("delete", strconv.Itoa(id))
+	}
+
+	now := t.now().Format(TimeFormat)
+	rec["is_deleted"] = "1"
+	rec["deleted_date"] = now
+	rec["modified_date"] = now
+
+	return nil
+}
+
+// Private
+
+func (t *MemoryService[T, PT]) modify(id int, changes Record) (*T, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now().Format(TimeFormat)
+
+	if id == 0 {
+		t.lastID++
+		id = t.lastID
+
+		changes["modified_date"] = now
+		if _, ok := changes["is_deleted"]; !ok {
+			changes["is_deleted"] = "0"
+		}
+		t.records[id] = changes
+
+		return t.decode(id, changes)
+	}
+
+	rec, ok := t.records[id]
+	if !ok {
+		return nil, t.notFound("modify", strconv.Itoa(id))
+	}
+
+	for key, value := range changes {
+		rec[key] = value
+	}
+	rec["modified_date"] = now
+
+	return t.decode(id, rec)
+}
+
+func (t *MemoryService[T, PT]) decode(id int, rec Record) (*T, error) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	var item T
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, err
+	}
+	PT(&item).SetID(id)
+
+	return &item, nil
+}
+
+func (t *MemoryService[T, PT]) notFound(action string, id string) error {
+	return &scoro.APIError{
+		Module:     t.module,
+		Action:     action,
+		ID:         id,
+		HTTPStatus: http.StatusNotFound,
+		StatusCode: strconv.Itoa(http.StatusNotFound),
+		Messages:   []string{"Object not found"},
+	}
+}
@@ -0,0 +1,111 @@
+package scorotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestMemoryService(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error
+		err  error
+	}{
+		{
+			name: "create assigns ID and modified_date",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				product, err := products.Modify(ctx, scoro.Product{Code: "C"})
+				if err == nil && (*product.Id != 3 || !product.ModifiedDate.Equal(now)) {
+					t.Errorf("got ID %d and modified_date %v", *product.Id, product.ModifiedDate)
+				}
+				return err
+			},
+		},
+		{
+			name: "update merges fields",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				id := 1
+				product, err := products.Modify(ctx, scoro.Product{Id: &id, Name: "Renamed"})
+				if err == nil && (product.Code != "A" || product.Name != "Renamed") {
+					t.Errorf("got code %q and name %q", product.Code, product.Name)
+				}
+				return err
+			},
+		},
+		{
+			name: "update sends only specified fields",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				product, err := products.ModifyFields(ctx, map[string]interface{}{"product_id": 1, "name": "Renamed"})
+				if err == nil && (product.Code != "A" || product.Name != "Renamed" || !product.IsActive.Value) {
+					t.Errorf("got code %q, name %q and is_active %v", product.Code, product.Name, product.IsActive)
+				}
+				return err
+			},
+		},
+		{
+			name: "update of missing record",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				id := 99
+				_, err := products.Modify(ctx, scoro.Product{Id: &id})
+				return err
+			},
+			err: scoro.ErrNotFound,
+		},
+		{
+			name: "list with filter",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				list, err := products.List(ctx, scoro.ProductFilter{Code: "B"}, 1, 10)
+				if err == nil && (len(list) != 1 || *list[0].Id != 2) {
+					t.Errorf("got %d products", len(list))
+				}
+				return err
+			},
+		},
+		{
+			name: "deleted record isn't listed",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				if err := products.Delete(ctx, 1); err != nil {
+					return err
+				}
+
+				list, err := scoro.NewPager(products.List, nil).Collect(ctx)
+				if err == nil && len(list) != 1 {
+					t.Errorf("got %d products, want 1", len(list))
+				}
+				return err
+			},
+		},
+		{
+			name: "view missing record",
+			call: func(ctx context.Context, products *MemoryService[scoro.Product, *scoro.Product]) error {
+				_, err := products.View(ctx, "99")
+				return err
+			},
+			err: scoro.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			products := NewMemoryService[scoro.Product]("products")
+			products.SetNow(func() time.Time { return now })
+
+			for _, code := range []string{"A", "B"} {
+				if _, err := products.Modify(ctx, scoro.Product{Code: code, IsActive: scoro.Bool{Value: true}}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := test.call(ctx, products); !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
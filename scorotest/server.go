@@ -0,0 +1,614 @@
+// Package scorotest provides in-memory fake of Scoro API for testing code
+// that uses go-scoro.
+//
+// Server implements /api/{v1|v2}/{module}/{view|list|modify|delete} protocol of
+// products, quotes, orders, invoices, prepayments, contacts, receipts and
+// relations modules. It checks credentials, assigns IDs to created records,
+// stamps modified_date, deletes records softly (is_deleted) and supports basic
+// list filters and pagination.
+//
+// Example:
+//
+//		server := scorotest.NewServer(credentials)
+//		defer server.Close()
+//
+//		client := server.Client()
+//		product, err := client.Products().Modify(ctx, scoro.Product{Code: "1"})
+//
+// Hooks can be used to inject errors and latency:
+//
+//		server.AddHook(scorotest.FailTimes(2, scorotest.Fault{HTTPStatus: 503}))
+package scorotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+// TimeFormat is format of modified_date and deleted_date stamped by server.
+const TimeFormat = "2006-01-02 15:04:05"
+
+// DefaultPerPage is page size of list requests without per_page.
+const DefaultPerPage = 10
+
+// Record is stored record of a module, as it is represented in JSON.
+type Record map[string]interface{}
+
+// Request describes API request received by server, it is passed to hooks.
+type Request struct {
+	Module string
+	Action string
+	ID     string
+	Body   map[string]interface{}
+}
+
+// Fault is error response injected by hook. Latency is applied before
+// response is sent.
+type Fault struct {
+	HTTPStatus int
+	StatusCode int
+	Messages   []string
+	RetryAfter time.Duration
+	Latency    time.Duration
+}
+
+// Hook is called for each request before it is handled. Returned fault is
+// sent instead of normal response, nil lets request through.
+type Hook func(req *Request) *Fault
+
+// FailTimes returns hook failing first n requests with specified fault.
+func FailTimes(n int, fault Fault) Hook {
+	var mu sync.Mutex
+
+	return func(req *Request) *Fault {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if n <= 0 {
+			return nil
+		}
+		n--
+
+		return &fault
+	}
+}
+
+// Server is in-memory fake of Scoro API served by httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	credentials scoro.Credentials
+
+	mu      sync.Mutex
+	modules map[string]*module
+	hooks   []Hook
+	latency time.Duration
+	now     func() time.Time
+}
+
+// NewServer starts server accepting specified credentials.
+func NewServer(credentials scoro.Credentials) *Server {
+	t := &Server{
+		credentials: credentials,
+		modules:     make(map[string]*module),
+		now:         time.Now,
+	}
+
+	for name, idField := range defaultModules {
+		t.AddModule(name, idField)
+	}
+
+	t.Server = httptest.NewServer(http.HandlerFunc(t.serveHTTP))
+
+	return t
+}
+
+// Client returns client pointed to the server.
+func (t *Server) Client() *scoro.Client {
+	return scoro.NewClient(t.credentials).SetBaseURL(t.URL + "/api")
+}
+
+// AddModule registers module with specified name of ID field, so custom
+// entities can be served.
+func (t *Server) AddModule(name string, idField string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.modules[name] = &module{idField: idField, records: make(map[int]Record)}
+}
+
+// AddHook registers hook called before each request.
+func (t *Server) AddHook(hook Hook) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.hooks = append(t.hooks, hook)
+}
+
+// SetLatency sets delay of every response.
+func (t *Server) SetLatency(latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.latency = latency
+}
+
+// SetNow overrides clock used to stamp modified_date and deleted_date.
+func (t *Server) SetNow(now func() time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.now = now
+}
+
+// Put stores record in module as is, without stamping. Record can be any
+// value marshalled to JSON object, e.g. scoro.Product. Record without ID gets
+// new one, which is returned.
+func (t *Server) Put(moduleName string, record interface{}) (int, error) {
+	rec, err := toRecord(record)
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m, ok := t.modules[moduleName]
+	if !ok {
+		return 0, fmt.Errorf("scorotest: unknown module %v", moduleName)
+	}
+
+	return m.put(rec), nil
+}
+
+// Records returns copies of all records of module, including deleted ones,
+// ordered by ID.
+func (t *Server) Records(moduleName string) []Record {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m, ok := t.modules[moduleName]
+	if !ok {
+		return nil
+	}
+
+	var result []Record
+	for _, id := range m.ids() {
+		result = append(result, m.records[id].clone())
+	}
+
+	return result
+}
+
+// Private
+
+var defaultModules = map[string]string{
+	"products":             "product_id",
+	"quotes":               "id",
+	"orders":               "id",
+	"invoices":             "id",
+	"invoices/prepayments": "id",
+	"contacts":             "contact_id",
+	"receipts":             "receipt_id",
+	"relations":            "object_id",
+}
+
+var actions = map[string]bool{"view": true, "list": true, "modify": true, "delete": true}
+
+type module struct {
+	idField string
+	records map[int]Record
+	lastID  int
+}
+
+type response struct {
+	httpStatus int
+	statusCode int
+	messages   []string
+	data       interface{}
+	retryAfter time.Duration
+}
+
+func (t *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := t.handle(r)
+
+	status := "OK"
+	var messages interface{}
+	if resp.statusCode != http.StatusOK {
+		status = "ERROR"
+		messages = map[string][]string{"error": resp.messages}
+	}
+
+	if resp.retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(resp.retryAfter.Seconds())))
+	}
+
+	// API v2 responds with numeric status code.
+	var statusCode interface{} = strconv.Itoa(resp.statusCode)
+	if apiVersion(r.URL.Path) == scoro.APIv2 {
+		statusCode = resp.statusCode
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.httpStatus)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":     status,
+		"statusCode": statusCode,
+		"messages":   messages,
+		"data":       resp.data,
+	})
+}
+
+func (t *Server) handle(r *http.Request) response {
+	if r.Method != http.MethodPost {
+		return errorResponse(http.StatusMethodNotAllowed, "Method not allowed")
+	}
+
+	req, ok := parsePath(r.URL.Path)
+	if !ok {
+		return errorResponse(http.StatusNotFound, "Unknown endpoint")
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&req.Body); err != nil {
+		return errorResponse(http.StatusBadRequest, "Invalid request body")
+	}
+
+	t.mu.Lock()
+	hooks := t.hooks
+	latency := t.latency
+	t.mu.Unlock()
+
+	for _, hook := range hooks {
+		if fault := hook(req); fault != nil {
+			time.Sleep(latency + fault.Latency)
+			return faultResponse(fault)
+		}
+	}
+	time.Sleep(latency)
+
+	if str(req.Body["apiKey"]) != t.credentials.ApiKey || str(req.Body["company_account_id"]) != t.credentials.CompanyID {
+		return errorResponse(http.StatusUnauthorized, "Authentication failed")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m, ok := t.modules[req.Module]
+	if !ok {
+		return errorResponse(http.StatusNotFound, "Unknown module "+req.Module)
+	}
+
+	switch req.Action {
+	case "view":
+		return t.view(m, req)
+	case "list":
+		return t.list(m, req)
+	case "modify":
+		return t.modify(m, req)
+	default:
+		return t.delete(m, req)
+	}
+}
+
+func (t *Server) view(m *module, req *Request) response {
+	id, err := strconv.Atoi(req.ID)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "Invalid id")
+	}
+
+	rec, ok := m.records[id]
+	if !ok {
+		return errorResponse(http.StatusNotFound, "Object not found")
+	}
+
+	return okResponse(rec.clone())
+}
+
+func (t *Server) list(m *module, req *Request) response {
+	filter, _ := req.Body["filter"].(map[string]interface{})
+
+	var matched []Record
+	for _, id := range m.ids() {
+		rec := m.records[id]
+		if matchFilter(rec, filter) {
+			matched = append(matched, rec.clone())
+		}
+	}
+
+	page, _ := strconv.Atoi(str(req.Body["page"]))
+	if page < 1 {
+		page = 1
+	}
+
+	perPage, _ := strconv.Atoi(str(req.Body["per_page"]))
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+
+	start := (page - 1) * perPage
+	if start > len(matched) {
+		start = len(matched)
+	}
+
+	end := start + perPage
+	if end > len(matched) {
+		end = len(matched)
+	}
+
+	return okResponse(append([]Record{}, matched[start:end]...))
+}
+
+func (t *Server) modify(m *module, req *Request) response {
+	changes, ok := req.Body["request"].(map[string]interface{})
+	if !ok {
+		return errorResponse(http.StatusBadRequest, "Invalid request")
+	}
+
+	now := t.now().Format(TimeFormat)
+
+	id, _ := strconv.Atoi(str(changes[m.idField]))
+	if id == 0 {
+		rec := Record(changes).clone()
+		delete(rec, m.idField)
+		rec["modified_date"] = now
+		if _, ok := rec["is_deleted"]; !ok {
+			rec["is_deleted"] = "0"
+		}
+
+		id = m.put(rec)
+		return okResponse(m.records[id].clone())
+	}
+
+	rec, ok := m.records[id]
+	if !ok {
+		return errorResponse(http.StatusNotFound, "Object not found")
+	}
+
+	for key, value := range changes {
+		rec[key] = value
+	}
+	rec[m.idField] = id
+	rec["modified_date"] = now
+
+	return okResponse(rec.clone())
+}
+
+func (t *Server) delete(m *module, req *Request) response {
+	id, err := strconv.Atoi(req.ID)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "Invalid id")
+	}
+
+	rec, ok := m.records[id]
+	if !ok || str(rec["is_deleted"]) == "1" {
+		return errorResponse(http.StatusNotFound, "Object not found")
+	}
+
+	now := t.now().Format(TimeFormat)
+	rec["is_deleted"] = "1"
+	rec["deleted_date"] = now
+	rec["modified_date"] = now
+
+	return okResponse(nil)
+}
+
+func (t *module) put(rec Record) int {
+	id, _ := strconv.Atoi(str(rec[t.idField]))
+	if id == 0 {
+		t.lastID++
+		id = t.lastID
+	} else if id > t.lastID {
+		t.lastID = id
+	}
+
+	rec[t.idField] = id
+	t.records[id] = rec
+
+	return id
+}
+
+func (t *module) ids() []int {
+	ids := make([]int, 0, len(t.records))
+	for id := range t.records {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids
+}
+
+func (t Record) clone() Record {
+	data, _ := json.Marshal(t)
+
+	var rec Record
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	decoder.Decode(&rec)
+
+	return rec
+}
+
+// parsePath splits /api/{version}/{module}/{action}[/{id}] path, module name
+// can contain slashes, e.g. invoices/prepayments.
+func parsePath(path string) (*Request, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "api" || apiVersion(path) == "" {
+		return nil, false
+	}
+	parts = parts[2:]
+
+	req := &Request{}
+	last := parts[len(parts)-1]
+	if !actions[last] {
+		req.ID = last
+		parts = parts[:len(parts)-1]
+	}
+
+	if len(parts) < 2 || !actions[parts[len(parts)-1]] {
+		return nil, false
+	}
+
+	req.Action = parts[len(parts)-1]
+	req.Module = strings.Join(parts[:len(parts)-1], "/")
+
+	return req, true
+}
+
+// apiVersion returns version of API endpoint path, or empty string if the
+// version isn't supported.
+func apiVersion(path string) scoro.APIVersion {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+
+	switch version := scoro.APIVersion(parts[1]); version {
+	case scoro.APIv1, scoro.APIv2:
+		return version
+	}
+
+	return ""
+}
+
+// matchFilter checks record against filter. Deleted records are matched only
+// if filter has is_deleted field. Scalars are compared for equality, arrays
+// match any of the values and {"from_date", "to_date"} objects match ranges.
+func matchFilter(rec Record, filter map[string]interface{}) bool {
+	if _, ok := filter["is_deleted"]; !ok && str(rec["is_deleted"]) == "1" {
+		return false
+	}
+
+	for key, expected := range filter {
+		if !matchValue(rec[key], expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchValue(actual interface{}, expected interface{}) bool {
+	switch expected := expected.(type) {
+	case []interface{}:
+		for _, value := range expected {
+			if matchValue(actual, value) {
+				return true
+			}
+		}
+
+		return false
+	case map[string]interface{}:
+		if from, ok := expected["from_date"]; ok && str(actual) < str(from) {
+			return false
+		}
+
+		if to, ok := expected["to_date"]; ok {
+			value, toStr := str(actual), str(to)
+			if len(toStr) == len("2006-01-02") && len(value) > len(toStr) {
+				value = value[:len(toStr)]
+			}
+
+			if value > toStr {
+				return false
+			}
+		}
+
+		if _, isRange := expected["from_date"]; isRange {
+			return true
+		}
+		if _, isRange := expected["to_date"]; isRange {
+			return true
+		}
+
+		// Nested objects, e.g. custom_fields, are matched field by field.
+		nested, _ := actual.(map[string]interface{})
+		for key, value := range expected {
+			if !matchValue(nested[key], value) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return str(actual) == str(expected)
+}
+
+// str converts JSON value to string for comparison, booleans are converted
+// to "1"/"0" the same way as scoro.Bool does.
+func str(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		if value {
+			return "1"
+		}
+		return "0"
+	}
+
+	return fmt.Sprint(value)
+}
+
+func toRecord(value interface{}) (Record, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec Record
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rec); err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+func okResponse(data interface{}) response {
+	return response{httpStatus: http.StatusOK, statusCode: http.StatusOK, data: data}
+}
+
+func errorResponse(status int, message string) response {
+	return response{httpStatus: status, statusCode: status, messages: []string{message}}
+}
+
+func faultResponse(fault *Fault) response {
+	resp := response{
+		httpStatus: fault.HTTPStatus,
+		statusCode: fault.StatusCode,
+		messages:   fault.Messages,
+		retryAfter: fault.RetryAfter,
+	}
+
+	if resp.httpStatus == 0 && resp.statusCode == 0 {
+		resp.httpStatus = http.StatusInternalServerError
+	} else if resp.httpStatus == 0 {
+		resp.httpStatus = http.StatusOK
+	}
+
+	if resp.statusCode == 0 {
+		resp.statusCode = resp.httpStatus
+	}
+
+	if len(resp.messages) == 0 {
+		resp.messages = []string{http.StatusText(resp.statusCode)}
+	}
+
+	return resp
+}
@@ -0,0 +1,228 @@
+package scorotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+var testCredentials = scoro.Credentials{ApiKey: "key", CompanyID: "company"}
+
+func TestServer(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(ctx context.Context, client *scoro.Client) error
+		err  error
+	}{
+		{
+			name: "view",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				product, err := client.Products().View(ctx, "1")
+				if err == nil && product.Code != "A" {
+					t.Errorf("got code %q, want A", product.Code)
+				}
+				return err
+			},
+		},
+		{
+			name: "view missing record",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				_, err := client.Products().View(ctx, "99")
+				return err
+			},
+			err: scoro.ErrNotFound,
+		},
+		{
+			name: "create assigns ID and modified_date",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				product, err := client.Products().Modify(ctx, scoro.Product{Code: "C"})
+				if err == nil && (*product.Id != 3 || product.ModifiedDate.IsZero()) {
+					t.Errorf("got ID %d and modified_date %v", *product.Id, product.ModifiedDate)
+				}
+				return err
+			},
+		},
+		{
+			name: "update merges fields",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				id := 1
+				product, err := client.Products().Modify(ctx, scoro.Product{Id: &id, Name: "Renamed"})
+				if err == nil && (product.Code != "A" || product.Name != "Renamed") {
+					t.Errorf("got code %q and name %q", product.Code, product.Name)
+				}
+				return err
+			},
+		},
+		{
+			name: "list with filter",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				products, err := client.Products().List(ctx, scoro.ProductFilter{Code: "B"}, 1, 10)
+				if err == nil && (len(products) != 1 || *products[0].Id != 2) {
+					t.Errorf("got %d products", len(products))
+				}
+				return err
+			},
+		},
+		{
+			name: "list pages",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				products, err := client.Products().List(ctx, nil, 2, 1)
+				if err == nil && (len(products) != 1 || *products[0].Id != 2) {
+					t.Errorf("got %d products", len(products))
+				}
+				return err
+			},
+		},
+		{
+			name: "deleted record isn't listed",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				if err := client.Products().Delete(ctx, 1); err != nil {
+					return err
+				}
+
+				products, err := client.Products().ListAll(ctx, nil)
+				if err == nil && len(products) != 1 {
+					t.Errorf("got %d products, want 1", len(products))
+				}
+				return err
+			},
+		},
+		{
+			name: "deleted record can't be deleted again",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				if err := client.Products().Delete(ctx, 1); err != nil {
+					return err
+				}
+				return client.Products().Delete(ctx, 1)
+			},
+			err: scoro.ErrNotFound,
+		},
+		{
+			name: "prepayments module",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				invoice, err := client.PrepaymentInvoices().Modify(ctx, scoro.Invoice{No: "P1"})
+				if err == nil && *invoice.Id != 1 {
+					t.Errorf("got ID %d, want 1", *invoice.Id)
+				}
+				return err
+			},
+		},
+		{
+			name: "API v2",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				product, err := client.SetAPIVersion(scoro.APIv2).Products().View(ctx, "2")
+				if err == nil && product.Code != "B" {
+					t.Errorf("got code %q, want B", product.Code)
+				}
+				return err
+			},
+		},
+		{
+			name: "API v2 error",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				_, err := client.SetAPIVersion(scoro.APIv2).Products().View(ctx, "99")
+				return err
+			},
+			err: scoro.ErrNotFound,
+		},
+		{
+			name: "wrong credentials",
+			call: func(ctx context.Context, client *scoro.Client) error {
+				_, err := client.SetCredentialsProvider(scoro.StaticCredentials(scoro.Credentials{ApiKey: "wrong"})).Products().View(ctx, "1")
+				return err
+			},
+			err: scoro.ErrUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := NewServer(testCredentials)
+			defer srv.Close()
+
+			for _, code := range []string{"A", "B"} {
+				if _, err := srv.Put("products", scoro.Product{Code: code}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := test.call(context.Background(), srv.Client())
+			if !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestServerHooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		hook       Hook
+		latency    time.Duration
+		elapsed    time.Duration
+		retryAfter time.Duration
+		err        error
+	}{
+		{
+			name: "fault",
+			hook: FailTimes(1, Fault{HTTPStatus: http.StatusServiceUnavailable}),
+			err:  scoro.ErrServer,
+		},
+		{
+			name:       "fault with retry after",
+			hook:       FailTimes(1, Fault{HTTPStatus: http.StatusTooManyRequests, RetryAfter: time.Second}),
+			retryAfter: time.Second,
+			err:        scoro.ErrRateLimited,
+		},
+		{
+			name:    "fault latency",
+			hook:    FailTimes(1, Fault{HTTPStatus: http.StatusBadGateway, Latency: 50 * time.Millisecond}),
+			elapsed: 50 * time.Millisecond,
+			err:     scoro.ErrServer,
+		},
+		{
+			name:    "server latency",
+			latency: 50 * time.Millisecond,
+			elapsed: 50 * time.Millisecond,
+			err:     scoro.ErrNotFound,
+		},
+		{
+			name: "failures are limited",
+			hook: FailTimes(0, Fault{HTTPStatus: http.StatusServiceUnavailable}),
+			err:  scoro.ErrNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := NewServer(testCredentials)
+			defer srv.Close()
+
+			if test.hook != nil {
+				srv.AddHook(test.hook)
+			}
+			srv.SetLatency(test.latency)
+
+			start := time.Now()
+			_, err := srv.Client().Products().View(context.Background(), "1")
+			elapsed := time.Since(start)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+
+			if elapsed < test.elapsed {
+				t.Errorf("response took %v, want at least %v", elapsed, test.elapsed)
+			}
+
+			var apiErr *scoro.APIError
+			if errors.As(err, &apiErr) && apiErr.RetryAfter != test.retryAfter {
+				t.Errorf("got retry after %v, want %v", apiErr.RetryAfter, test.retryAfter)
+			}
+		})
+	}
+}
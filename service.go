@@ -0,0 +1,134 @@
+package scoro
+
+import (
+	"context"
+)
+
+// Service provides type safe wrappers for View/List/Modify/Delete actions
+// of a single Scoro API module. T is data type of the module records.
+//
+// Services of supported modules are returned by Client methods, e.g.
+// client.Products(). NewService can be used to access modules that aren't
+// implemented by the library yet:
+//
+//		type Project struct {
+//			Id   *int   `json:"project_id,omitempty"`
+//			Name string `json:"project_name,omitempty"`
+//		}
+//
+//		projects := scoro.NewService[Project](client, "projects")
+//		project, err := projects.View(ctx, "1")
+type Service[T any] struct {
+	client *Client
+	module string
+	lang   string
+}
+
+// NewService creates service of specified module bound to the client.
+func NewService[T any](client *Client, module string) Service[T] {
+	return Service[T]{
+		client: client,
+		module: module,
+	}
+}
+
+// SetLang returns copy of the service sending requests in specified
+// language, language of the client is used by default.
+//
+//		product, err := client.Products().SetLang("est").View(ctx, id)
+func (t Service[T]) SetLang(lang string) Service[T] {
+	t.lang = lang
+	return t
+}
+
+// Module returns name of Scoro API module of the service.
+func (t Service[T]) Module() string {
+	return t.module
+}
+
+func (t Service[T]) View(ctx context.Context, id string) (*T, error) {
+	resp, err := t.Request().SetResponse(itemResponse[T]{}).View(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp.(*itemResponse[T])
+	if !ok {
+		return nil, invalidResponse(t.module, "view", resp)
+	}
+
+	return &result.Data, nil
+}
+
+func (t Service[T]) List(ctx context.Context, filter Filter, page int, count int) ([]T, error) {
+	resp, err := t.Request().SetResponse(listResponse[T]{}).List(ctx, filter, page, count)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp.(*listResponse[T])
+	if !ok {
+		return nil, invalidResponse(t.module, "list", resp)
+	}
+
+	return result.Data, nil
+}
+
+func (t Service[T]) Modify(ctx context.Context, obj T) (*T, error) {
+	return t.modify(ctx, obj)
+}
+
+func (t Service[T]) ModifyFields(ctx context.Context, fields map[string]interface{}) (*T, error) {
+	return t.modify(ctx, fields)
+}
+
+func (t Service[T]) Delete(ctx context.Context, id int) error {
+	_, err := t.Request().Delete(ctx, id, nil)
+
+	return err
+}
+
+// Pager returns pager iterating over records matching filter.
+func (t Service[T]) Pager(filter Filter) *Pager[T] {
+	return NewPager(t.List, filter)
+}
+
+// ListAll loads all records matching filter.
+func (t Service[T]) ListAll(ctx context.Context, filter Filter) ([]T, error) {
+	return t.Pager(filter).Collect(ctx)
+}
+
+func (t Service[T]) Request() Request {
+	request := NewRequest(t.client, t.module)
+	if t.lang != "" {
+		request = request.SetLang(t.lang)
+	}
+
+	return request
+}
+
+// Private
+
+func (t Service[T]) modify(ctx context.Context, obj interface{}) (*T, error) {
+	resp, err := t.Request().SetResponse(itemResponse[T]{}).Modify(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	result, ok := resp.(*itemResponse[T])
+	if !ok {
+		return nil, invalidResponse(t.module, "modify", resp)
+	}
+
+	return &result.Data, nil
+}
+
+type itemResponse[T any] struct {
+	ResponseHeader `json:",inline"`
+	Data           T `json:"data,omitempty"`
+}
+
+type listResponse[T any] struct {
+	ResponseHeader `json:",inline"`
+	Data           []T `json:"data,omitempty"`
+}
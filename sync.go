@@ -0,0 +1,315 @@
+package scoro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SyncEntity is implemented by pointers to data types which have
+// modified_date field and can be synced incrementally.
+type SyncEntity[T any] interface {
+	Entity[T]
+	GetModifiedDate() Time
+}
+
+// Cursor is position of incremental sync: modification time of the last
+// synced records and IDs of records synced with exactly that time. Scoro
+// stores modification time with one second precision, so several records
+// can share it, and records modified later within the same second are
+// synced by the next run.
+type Cursor struct {
+	ModifiedDate time.Time `json:"modified_date"`
+	IDs          []int     `json:"ids,omitempty"`
+}
+
+// CursorStore persists sync cursors by key.
+type CursorStore interface {
+	// Load returns cursor stored by key or zero cursor if there is none.
+	Load(ctx context.Context, key string) (Cursor, error)
+
+	// Save stores cursor by key.
+	Save(ctx context.Context, key string, cursor Cursor) error
+}
+
+// Syncer loads records changed since the last run, ordered by modification
+// time, and advances cursor stored in CursorStore as they are handled.
+//
+// Example:
+//
+//		store := scoro.NewFileCursorStore("cursors.json")
+//		syncer := scoro.NewSyncer[scoro.Invoice](client.Invoices(), store, "invoices")
+//
+//		n, err := syncer.Run(ctx, func(ctx context.Context, invoice scoro.Invoice) error {
+//			return saveInvoice(ctx, invoice)
+//		})
+//
+// Scoro doesn't order lists by modification time, so each run loads all
+// changed records before handling the first of them, and the first run loads
+// all records matching filter. Cursor is saved after every page size of
+// handled records and when handler fails, so the next run continues from the
+// first unhandled record.
+//
+// Deleted records are synced too unless filter has is_deleted field, records
+// implementing Deleted method report whether they are deleted.
+type Syncer[T any, PT SyncEntity[T]] struct {
+	service  EntityService[T]
+	store    CursorStore
+	key      string
+	filter   Filter
+	pageSize int
+}
+
+// NewSyncer creates syncer of records of service, which stores its cursor
+// in store by specified key.
+func NewSyncer[T any, PT SyncEntity[T]](service EntityService[T], store CursorStore, key string) *Syncer[T, PT] {
+	return &Syncer[T, PT]{
+		service:  service,
+		store:    store,
+		key:      key,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// SetFilter sets filter of synced records, its modified_date field is
+// replaced by cursor.
+func (t *Syncer[T, PT]) SetFilter(filter Filter) *Syncer[T, PT] {
+	t.filter = filter
+	return t
+}
+
+// SetPageSize sets number of records requested per page, it is also number
+// of handled records between cursor saves.
+func (t *Syncer[T, PT]) SetPageSize(pageSize int) *Syncer[T, PT] {
+	if pageSize > 0 {
+		t.pageSize = pageSize
+	}
+	return t
+}
+
+// Run loads all records changed since stored cursor and passes them to
+// handle one by one in order of modification. It returns number of handled
+// records.
+func (t *Syncer[T, PT]) Run(ctx context.Context, handle func(ctx context.Context, record T) error) (int, error) {
+	cursor, err := t.store.Load(ctx, t.key)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := NewPager(t.service.List, t.changedSince(cursor)).SetPageSize(t.pageSize).Collect(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	records = t.pending(cursor, records)
+
+	handled := 0
+	for _, record := range records {
+		if err := handle(ctx, record); err != nil {
+			return handled, t.saveAfterFailure(ctx, cursor, err)
+		}
+
+		cursor = advance(cursor, PT(&record).GetID(), PT(&record).GetModifiedDate().Time)
+		handled++
+
+		if handled%t.pageSize == 0 {
+			if err := t.store.Save(ctx, t.key, cursor); err != nil {
+				return handled, err
+			}
+		}
+	}
+
+	if handled%t.pageSize != 0 {
+		if err := t.store.Save(ctx, t.key, cursor); err != nil {
+			return handled, err
+		}
+	}
+
+	return handled, nil
+}
+
+// MemoryCursorStore keeps cursors in memory.
+type MemoryCursorStore struct {
+	mu      sync.Mutex
+	cursors map[string]Cursor
+}
+
+// NewMemoryCursorStore creates empty in-memory store.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: make(map[string]Cursor)}
+}
+
+// Load implements CursorStore.
+func (t *MemoryCursorStore) Load(ctx context.Context, key string) (Cursor, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.cursors[key], nil
+}
+
+// Save implements CursorStore.
+func (t *MemoryCursorStore) Save(ctx context.Context, key string, cursor Cursor) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cursors[key] = cursor
+	return nil
+}
+
+// FileCursorStore keeps cursors of all keys in single JSON file. File is
+// replaced atomically on each save.
+type FileCursorStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCursorStore creates store of specified file, file is created on the
+// first save.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+// Load implements CursorStore.
+func (t *FileCursorStore) Load(ctx context.Context, key string) (Cursor, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cursors, err := t.read()
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	return cursors[key], nil
+}
+
+// Save implements CursorStore.
+func (t *FileCursorStore) Save(ctx context.Context, key string, cursor Cursor) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cursors, err := t.read()
+	if err != nil {
+		return err
+	}
+	cursors[key] = cursor
+
+	data, err := json.MarshalIndent(cursors, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(t.path), filepath.Base(t.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), t.path)
+}
+
+// Private
+
+// changedSince returns filter of deleted and existing records modified at or
+// after cursor time, records with cursor time are needed to pick up ties.
+func (t *Syncer[T, PT]) changedSince(cursor Cursor) Filter {
+	values := make(filterValues)
+	if t.filter != nil {
+		for key, value := range t.filter.FilterValues() {
+			values[key] = value
+		}
+	}
+
+	// Deleted records are listed only if filter has is_deleted field.
+	if _, ok := values["is_deleted"]; !ok {
+		values["is_deleted"] = []Bool{{Value: false}, {Value: true}}
+	}
+
+	delete(values, "modified_date")
+	values.setTimeRange("modified_date", TimeRange{From: cursor.ModifiedDate})
+
+	return RawFilter(values)
+}
+
+// pending drops records synced before cursor and sorts the rest by
+// modification time and ID.
+func (t *Syncer[T, PT]) pending(cursor Cursor, records []T) []T {
+	synced := make(map[int]bool, len(cursor.IDs))
+	for _, id := range cursor.IDs {
+		synced[id] = true
+	}
+
+	result := records[:0]
+	for _, record := range records {
+		modified := PT(&record).GetModifiedDate().Time
+		if modified.Before(cursor.ModifiedDate) {
+			continue
+		}
+
+		if modified.Equal(cursor.ModifiedDate) && synced[PT(&record).GetID()] {
+			continue
+		}
+
+		result = append(result, record)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := PT(&result[i]), PT(&result[j])
+		if !a.GetModifiedDate().Time.Equal(b.GetModifiedDate().Time) {
+			return a.GetModifiedDate().Time.Before(b.GetModifiedDate().Time)
+		}
+
+		return a.GetID() < b.GetID()
+	})
+
+	return result
+}
+
+func (t *Syncer[T, PT]) saveAfterFailure(ctx context.Context, cursor Cursor, err error) error {
+	if saveErr := t.store.Save(ctx, t.key, cursor); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	return err
+}
+
+// advance moves cursor past handled record.
+func advance(cursor Cursor, id int, modified time.Time) Cursor {
+	if modified.After(cursor.ModifiedDate) {
+		return Cursor{ModifiedDate: modified, IDs: []int{id}}
+	}
+
+	cursor.IDs = append(cursor.IDs[:len(cursor.IDs):len(cursor.IDs)], id)
+	return cursor
+}
+
+func (t *FileCursorStore) read() (map[string]Cursor, error) {
+	cursors := make(map[string]Cursor)
+
+	data, err := os.ReadFile(t.path)
+	if os.IsNotExist(err) {
+		return cursors, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &cursors); err != nil {
+		return nil, err
+	}
+
+	return cursors, nil
+}
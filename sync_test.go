@@ -0,0 +1,186 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/scorotest"
+)
+
+func TestSyncer(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time {
+		return base.Add(time.Duration(seconds) * time.Second)
+	}
+
+	type record struct {
+		modified int
+		deleted  bool
+	}
+
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name    string
+		cursor  scoro.Cursor
+		records []record
+		failID  int
+		handled []int
+		want    scoro.Cursor
+		err     error
+	}{
+		{
+			name:    "records are handled in order of modification",
+			records: []record{{modified: 2}, {modified: 0}, {modified: 1}},
+			handled: []int{2, 3, 1},
+			want:    scoro.Cursor{ModifiedDate: at(2), IDs: []int{1}},
+		},
+		{
+			name:    "records before cursor are skipped",
+			cursor:  scoro.Cursor{ModifiedDate: at(1)},
+			records: []record{{modified: 0}, {modified: 1}, {modified: 2}},
+			handled: []int{2, 3},
+			want:    scoro.Cursor{ModifiedDate: at(2), IDs: []int{3}},
+		},
+		{
+			name:    "ties synced before are skipped",
+			cursor:  scoro.Cursor{ModifiedDate: at(1), IDs: []int{2}},
+			records: []record{{modified: 0}, {modified: 1}, {modified: 1}},
+			handled: []int{3},
+			want:    scoro.Cursor{ModifiedDate: at(1), IDs: []int{2, 3}},
+		},
+		{
+			name:    "deleted records are synced",
+			records: []record{{modified: 0}, {modified: 1, deleted: true}},
+			handled: []int{1, 2},
+			want:    scoro.Cursor{ModifiedDate: at(1), IDs: []int{2}},
+		},
+		{
+			name:    "failed handler keeps cursor of handled records",
+			records: []record{{modified: 0}, {modified: 0}, {modified: 1}},
+			failID:  2,
+			handled: []int{1},
+			want:    scoro.Cursor{ModifiedDate: at(0), IDs: []int{1}},
+			err:     errHandler,
+		},
+		{
+			name:    "nothing changed",
+			cursor:  scoro.Cursor{ModifiedDate: at(0), IDs: []int{1}},
+			records: []record{{modified: 0}},
+			want:    scoro.Cursor{ModifiedDate: at(0), IDs: []int{1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			srv := newTestServer(t)
+			for _, record := range test.records {
+				isDeleted := "0"
+				if record.deleted {
+					isDeleted = "1"
+				}
+
+				rec := scorotest.Record{"modified_date": at(record.modified).Format(scorotest.TimeFormat), "is_deleted": isDeleted}
+				if _, err := srv.Put("products", rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			store := scoro.NewMemoryCursorStore()
+			if err := store.Save(ctx, "products", test.cursor); err != nil {
+				t.Fatal(err)
+			}
+
+			syncer := scoro.NewSyncer[scoro.Product](srv.Client().Products(), store, "products").SetPageSize(2)
+
+			var handled []int
+			n, err := syncer.Run(ctx, func(ctx context.Context, product scoro.Product) error {
+				if *product.Id == test.failID {
+					return errHandler
+				}
+
+				handled = append(handled, *product.Id)
+				return nil
+			})
+
+			if !errors.Is(err, test.err) {
+				t.Fatalf("got error %v, want %v", err, test.err)
+			}
+
+			if n != len(test.handled) || fmt.Sprint(handled) != fmt.Sprint(test.handled) {
+				t.Errorf("got %d handled %v, want %v", n, handled, test.handled)
+			}
+
+			cursor, err := store.Load(ctx, "products")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !cursor.ModifiedDate.Equal(test.want.ModifiedDate) || fmt.Sprint(cursor.IDs) != fmt.Sprint(test.want.IDs) {
+				t.Errorf("got cursor %v %v, want %v %v", cursor.ModifiedDate, cursor.IDs, test.want.ModifiedDate, test.want.IDs)
+			}
+		})
+	}
+}
+
+func TestSyncerSaveFailure(t *testing.T) {
+	srv := newTestServer(t)
+	if _, err := srv.Put("products", scoro.Product{Code: "A"}); err != nil {
+		t.Fatal(err)
+	}
+
+	errHandler := errors.New("handler failed")
+	errStore := errors.New("store failed")
+
+	syncer := scoro.NewSyncer[scoro.Product](srv.Client().Products(), failingStore{err: errStore}, "products")
+	_, err := syncer.Run(context.Background(), func(ctx context.Context, product scoro.Product) error {
+		return errHandler
+	})
+
+	if !errors.Is(err, errHandler) || !errors.Is(err, errStore) {
+		t.Errorf("got error %v, want both %v and %v", err, errHandler, errStore)
+	}
+}
+
+func TestFileCursorStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cursors.json")
+
+	cursor, err := scoro.NewFileCursorStore(path).Load(ctx, "products")
+	if err != nil || !cursor.ModifiedDate.IsZero() {
+		t.Fatalf("got cursor %v and error %v of missing file", cursor, err)
+	}
+
+	want := scoro.Cursor{ModifiedDate: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), IDs: []int{1, 2}}
+	if err := scoro.NewFileCursorStore(path).Save(ctx, "products", want); err != nil {
+		t.Fatal(err)
+	}
+
+	cursor, err = scoro.NewFileCursorStore(path).Load(ctx, "products")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cursor.ModifiedDate.Equal(want.ModifiedDate) || fmt.Sprint(cursor.IDs) != fmt.Sprint(want.IDs) {
+		t.Errorf("got cursor %v, want %v", cursor, want)
+	}
+}
+
+// failingStore loads zero cursors and fails to save them.
+type failingStore struct {
+	err error
+}
+
+func (t failingStore) Load(ctx context.Context, key string) (scoro.Cursor, error) {
+	return scoro.Cursor{}, nil
+}
+
+func (t failingStore) Save(ctx context.Context, key string, cursor scoro.Cursor) error {
+	return t.err
+}
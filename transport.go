@@ -0,0 +1,144 @@
+package scoro
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Private
+
+// handler returns the whole request pipeline: middleware chain wrapped
+// around metrics, logging, mode guard and retries of transport round trips.
+func (t *Client) handler() Handler {
+	handler := Handler(t.retryRoundTrip)
+	if t.mode != ModeReadWrite {
+		handler = t.guarding(handler)
+	}
+
+	if t.logger != nil {
+		handler = t.logging(handler)
+	}
+
+	if t.metrics != nil {
+		handler = t.measuring(handler)
+	}
+
+	return chain(handler, t.middleware)
+}
+
+func (t *Client) retryRoundTrip(ctx context.Context, call *Call) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := t.attempt(ctx, call)
+		if resp != nil {
+			resp.Attempts = attempt
+		}
+
+		if err == nil || !t.retry.allows(call.Action) || attempt >= t.retry.MaxAttempts || !isRetryable(err) {
+			return resp, setAttempts(err, attempt)
+		}
+
+		delay, ok := t.retry.backoff(attempt, err)
+		if !ok {
+			return resp, setAttempts(err, attempt)
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			transportErr := &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+			return nil, setAttempts(transportErr, attempt)
+		}
+	}
+}
+
+// attempt sends single attempt of the call through rate limiter and circuit
+// breaker.
+func (t *Client) attempt(ctx context.Context, call *Call) (*Response, error) {
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx, call.Subdomain, call.CompanyID); err != nil {
+			return nil, &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+		}
+	}
+
+	if t.breaker == nil {
+		return t.roundTrip(ctx, call)
+	}
+
+	generation, err := t.breaker.allow()
+	if err != nil {
+		return nil, &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+	}
+
+	resp, err := t.roundTrip(ctx, call)
+	t.breaker.record(generation, err)
+
+	return resp, err
+}
+
+func (t *Client) roundTrip(ctx context.Context, call *Call) (*Response, error) {
+	envelope := envelopeFor(t.version)
+
+	data, err := envelope.authorize(call.credentials, call.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	url := t.makeUrl(call.credentials.Subdomain, call.Module, call.Action, call.ID)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range call.Header {
+		httpReq.Header[key] = values
+	}
+
+	httpResp, err := t.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+	}
+	defer httpResp.Body.Close()
+
+	respData, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, &TransportError{Module: call.Module, Action: call.Action, ID: call.ID, Err: err}
+	}
+
+	resp := &Response{
+		HTTPStatus: httpResp.StatusCode,
+		HTTPHeader: httpResp.Header,
+		Body:       respData,
+	}
+
+	// Error responses usually carry header with messages, but body can be
+	// anything, so decoding failures of them are ignored.
+	if err := envelope.decodeResponse(respData, &resp.Header); err != nil && resp.HTTPStatus == http.StatusOK {
+		return resp, &DecodeError{Module: call.Module, Action: call.Action, Err: err}
+	}
+
+	if resp.HTTPStatus != http.StatusOK || resp.Header.Status != "OK" {
+		return resp, newAPIError(call, resp)
+	}
+
+	return resp, nil
+}
+
+func (t *Client) makeUrl(subdomain string, entityType string, action string, id string) string {
+	baseURL := strings.Replace(t.baseURL, SubdomainPlaceholder, subdomain, -1)
+
+	urlParts := []string{strings.TrimSuffix(baseURL, "/"), string(t.version), entityType, action}
+	if id != "" {
+		urlParts = append(urlParts, id)
+	}
+
+	return strings.Join(urlParts, "/")
+}
+
+func (t *Client) header() http.Header {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("Accept", "application/json")
+	header.Set("User-Agent", t.userAgent)
+
+	return header
+}
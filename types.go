@@ -124,7 +124,8 @@ func (t *Bool) UnmarshalJSON(data []byte) error {
 // expects strings as localied dictionaries for some fields in request. However,
 // it can return them as localized dictionary as single string (in requested lang) for
 // the same fields in response. Marshal/Unmarshal implementations for this
-// type handle both cases appropriately.
+// type handle both cases appropriately. Single string received in response is
+// stored under the language of the request.
 //
 // Examples:
 //
@@ -132,6 +133,10 @@ func (t *Bool) UnmarshalJSON(data []byte) error {
 // 		field := scoro.MakeStrings("Привет", "rus")
 type Strings struct {
 	Values map[string]string `json:",inline"`
+
+	// single is set when value was received as single string, so its
+	// language is unknown until it is localized by request.
+	single bool
 }
 
 // MakeStrings is helper method that creates strings for single language, it
@@ -140,7 +145,12 @@ func MakeStrings(str string, lang string) Strings {
 	values := make(map[string]string)
 	values[lang] = str
 
-	return Strings{values}
+	return Strings{Values: values}
+}
+
+// Get returns string of specified language.
+func (t Strings) Get(lang string) string {
+	return t.Values[lang]
 }
 
 func (t Strings) MarshalJSON() ([]byte, error) {
@@ -162,12 +172,22 @@ func (t *Strings) UnmarshalJSON(data []byte) error {
 		err := json.Unmarshal(data, &defString)
 
 		if err == nil {
-			t.Values["eng"] = defString
+			t.Values[DefaultLang] = defString
+			t.single = true
 		}
 		return err
 	}
 
-	return json.Unmarshal(data, t.Values)
+	return json.Unmarshal(data, &t.Values)
+}
+
+// localize moves single string to specified language.
+func (t *Strings) localize(lang string) {
+	if !t.single || lang == DefaultLang {
+		return
+	}
+
+	t.Values = map[string]string{lang: t.Values[DefaultLang]}
 }
 
 // DecimalLike is interface for numeric values that can be represented as decimal
@@ -0,0 +1,188 @@
+package scoro
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrAmbiguousMatch is matched by errors of Upsert when natural key
+	// matches several records.
+	ErrAmbiguousMatch = errors.New("scoro: natural key matches several records")
+
+	// ErrEmptyKey is returned by Upsert when natural key of the record is empty.
+	ErrEmptyKey = errors.New("scoro: natural key is empty")
+)
+
+// NaturalKey returns name of filter field and value identifying record
+// besides its ID, e.g. "code" field and code of product.
+type NaturalKey[T any] func(record T) (field string, value string)
+
+// ProductByCode is natural key of products by code.
+func ProductByCode(product Product) (string, string) {
+	return "code", product.Code
+}
+
+// ContactByReferenceNo is natural key of contacts by reference number.
+func ContactByReferenceNo(contact Contact) (string, string) {
+	return "reference_no", contact.ReferenceNo
+}
+
+// ContactByIdCode is natural key of contacts by personal or registry code.
+func ContactByIdCode(contact Contact) (string, string) {
+	return "id_code", contact.IdCode
+}
+
+// InvoiceByNo is natural key of invoices by number.
+func InvoiceByNo(invoice Invoice) (string, string) {
+	return "no", invoice.No
+}
+
+// QuoteByNo is natural key of quotes by number.
+func QuoteByNo(quote Quote) (string, string) {
+	return "no", quote.No
+}
+
+// OrderByNo is natural key of orders by number.
+func OrderByNo(order Order) (string, string) {
+	return "no", order.No
+}
+
+// AmbiguousMatchError is returned by Upsert when natural key matches several
+// records.
+type AmbiguousMatchError struct {
+	Field string
+	Value string
+	IDs   []int
+}
+
+func (t *AmbiguousMatchError) Error() string {
+	return fmt.Sprintf("scoro: %v %q matches several records %v", t.Field, t.Value, t.IDs)
+}
+
+func (t *AmbiguousMatchError) Unwrap() error {
+	return ErrAmbiguousMatch
+}
+
+// UpsertService is part of service used by Upsert.
+type UpsertService[T any] interface {
+	List(ctx context.Context, filter Filter, page int, count int) ([]T, error)
+	FieldsModifier[T]
+}
+
+// Upsert creates record of service if there is no record with the same
+// natural key and updates the matching record otherwise. It returns saved
+// record and whether it was created.
+//
+//		product, created, err := scoro.Upsert(ctx, client.Products(), scoro.ProductByCode, product)
+//
+// Matching record is updated with fields of record which are set and differ
+// from the matching record only, so zero fields keep values of the matching
+// record. Record with ID must match the record with the same ID, records to
+// be created must have no ID. New record is created with set fields of
+// record only, so Scoro applies its defaults to the rest.
+//
+// Records found by key filter are compared by key value exactly, deleted
+// records aren't matched. Upsert isn't atomic, concurrent upserts of the same
+// key can create duplicates, which are reported by AmbiguousMatchError later.
+func Upsert[T any, PT Entity[T]](ctx context.Context, service UpsertService[T], key NaturalKey[T], record T) (*T, bool, error) {
+	field, value := key(record)
+	if value == "" {
+		return nil, false, fmt.Errorf("%w: %v", ErrEmptyKey, field)
+	}
+
+	candidates, err := NewPager(service.List, RawFilter{field: value}).Collect(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var matches []T
+	for _, candidate := range candidates {
+		if _, candidateValue := key(candidate); candidateValue == value {
+			matches = append(matches, candidate)
+		}
+	}
+
+	id := PT(&record).GetID()
+
+	switch len(matches) {
+	case 0:
+		if id != 0 {
+			return nil, false, fmt.Errorf("scoro: record %d doesn't match %v %q", id, field, value)
+		}
+
+		var empty T
+		changes, err := setChanges(empty, record)
+		if err != nil {
+			return nil, false, err
+		}
+
+		result, err := modifyChanges[T, PT](ctx, service, 0, changes)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return result, true, nil
+	case 1:
+		matched := matches[0]
+		matchedID := PT(&matched).GetID()
+		if id != 0 && id != matchedID {
+			return nil, false, fmt.Errorf("scoro: record %d doesn't match record %d with %v %q", id, matchedID, field, value)
+		}
+
+		changes, err := setChanges(matched, record)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(changes) == 0 {
+			return &matched, false, nil
+		}
+
+		result, err := modifyChanges[T, PT](ctx, service, matchedID, changes)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return result, false, nil
+	}
+
+	ids := make([]int, len(matches))
+	for i := range matches {
+		ids[i] = PT(&matches[i]).GetID()
+	}
+
+	return nil, false, &AmbiguousMatchError{Field: field, Value: value, IDs: ids}
+}
+
+// Private
+
+// setChanges returns fields of record which are set, i.e. aren't zero, and
+// differ from matched record.
+func setChanges[T any](matched T, record T) (map[string]json.RawMessage, error) {
+	before, err := marshalFields(matched)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffFields(before, record)
+	if err != nil {
+		return nil, err
+	}
+
+	zeros, err := zeroFields(record)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range changes {
+		if zero, ok := zeros[key]; ok && bytes.Equal(zero, value) {
+			delete(changes, key)
+		}
+	}
+
+	return changes, nil
+}
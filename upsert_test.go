@@ -0,0 +1,140 @@
+package scoro_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+func TestUpsert(t *testing.T) {
+	id := func(id int) *int {
+		return &id
+	}
+
+	tests := []struct {
+		name    string
+		record  scoro.Product
+		created bool
+		id      int
+		sent    []string
+		err     error
+	}{
+		{
+			name:    "record is created",
+			record:  scoro.Product{Code: "C", Name: "New"},
+			created: true,
+			id:      4,
+			sent:    []string{"code", "name"},
+		},
+		{
+			name:   "only set and changed fields are updated",
+			record: scoro.Product{Code: "A", Name: "Renamed", Tag: "x"},
+			id:     1,
+			sent:   []string{"name", "product_id"},
+		},
+		{
+			name:   "unchanged record isn't sent",
+			record: scoro.Product{Code: "A", Name: "First"},
+			id:     1,
+		},
+		{
+			name:   "record with matching ID is updated",
+			record: scoro.Product{Id: id(1), Code: "A", Name: "Renamed"},
+			id:     1,
+			sent:   []string{"name", "product_id"},
+		},
+		{
+			name:   "record with other ID",
+			record: scoro.Product{Id: id(2), Code: "A", Name: "Renamed"},
+			err:    errors.New("scoro: record 2 doesn't match record 1 with code \"A\""),
+		},
+		{
+			name:   "new record with ID",
+			record: scoro.Product{Id: id(5), Code: "C"},
+			err:    errors.New("scoro: record 5 doesn't match code \"C\""),
+		},
+		{
+			name:   "ambiguous key",
+			record: scoro.Product{Code: "B", Name: "Other"},
+			err:    scoro.ErrAmbiguousMatch,
+		},
+		{
+			name:   "empty key",
+			record: scoro.Product{Name: "Nameless"},
+			err:    scoro.ErrEmptyKey,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := newTestServer(t)
+			for _, product := range []scoro.Product{
+				{Code: "A", Name: "First", Tag: "x", IsActive: scoro.Bool{Value: true}},
+				{Code: "B", Name: "Second"},
+				{Code: "B", Name: "Third"},
+			} {
+				if _, err := srv.Put("products", product); err != nil {
+					t.Fatal(err)
+				}
+			}
+			modified := captureModify(srv)
+
+			product, created, err := scoro.Upsert(context.Background(), srv.Client().Products(), scoro.ProductByCode, test.record)
+			if test.err != nil {
+				if err == nil || !errors.Is(err, test.err) && err.Error() != test.err.Error() {
+					t.Fatalf("got error %v, want %v", err, test.err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if created != test.created {
+				t.Errorf("got created %v, want %v", created, test.created)
+			}
+
+			var sent []string
+			if requests := modified(); len(requests) > 0 {
+				sent = fieldNames(requests[0])
+			}
+
+			if fmt.Sprint(sent) != fmt.Sprint(test.sent) {
+				t.Errorf("got sent fields %v, want %v", sent, test.sent)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if *product.Id != test.id {
+				t.Errorf("got ID %d, want %d", *product.Id, test.id)
+			}
+
+			if !created && !product.IsActive.Value {
+				t.Error("is_active of matched record is reset")
+			}
+		})
+	}
+}
+
+func TestUpsertAmbiguousMatch(t *testing.T) {
+	srv := newTestServer(t)
+	for _, code := range []string{"A", "AB", "A"} {
+		if _, err := srv.Put("products", scoro.Product{Code: code}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, _, err := scoro.Upsert(context.Background(), srv.Client().Products(), scoro.ProductByCode, scoro.Product{Code: "A"})
+
+	var matchErr *scoro.AmbiguousMatchError
+	if !errors.As(err, &matchErr) {
+		t.Fatalf("got error %v, want AmbiguousMatchError", err)
+	}
+
+	if matchErr.Field != "code" || matchErr.Value != "A" || fmt.Sprint(matchErr.IDs) != "[1 3]" {
+		t.Errorf("got %v %q %v", matchErr.Field, matchErr.Value, matchErr.IDs)
+	}
+}
@@ -0,0 +1,290 @@
+package scoro
+
+import (
+	"context"
+	"iter"
+	"sort"
+	"time"
+)
+
+// EventType is type of change reported by Watch.
+type EventType int
+
+const (
+	// EventCreated reports record created after watching started.
+	EventCreated EventType = iota + 1
+
+	// EventUpdated reports modification of existing record.
+	EventUpdated
+
+	// EventDeleted reports deletion of record.
+	EventDeleted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventCreated:
+		return "created"
+	case EventUpdated:
+		return "updated"
+	case EventDeleted:
+		return "deleted"
+	}
+
+	return "unknown"
+}
+
+// Event is change of record reported by Watch.
+type Event[T any] struct {
+	Type   EventType
+	Record T
+}
+
+// WatchEntity is implemented by pointers to data types which have
+// modified_date and deleted_date fields and can be watched by Watch.
+type WatchEntity[T any] interface {
+	SyncEntity[T]
+	GetDeletedDate() Time
+}
+
+// Watch polls records of service matching filter every interval and returns
+// iterator over changes made after watching started. Existing records are
+// loaded by modified_date and deleted ones by deleted_date, so each poll
+// loads only records changed since the previous one. Each change is reported
+// once, even if it is returned by several polls.
+//
+// Watching starts with a scan of all records matching filter, which sets the
+// start to the latest modified_date and deleted_date stored by Scoro, so
+// times are compared in time zone of Scoro account regardless of the local
+// clock. The scan keeps only the highest ID, records with greater IDs are
+// reported as created, because Scoro doesn't report creation time. Scoro
+// stores times with one second precision, so another modification of record
+// within the same second as the reported one isn't reported.
+//
+// Next poll starts only after the consumer has received all changes of the
+// previous one, so slow consumer delays polling instead of accumulating
+// changes in memory. Failed polls yield error and are repeated after interval
+// unless the consumer stops the loop. Iteration ends when context is done.
+//
+//		for event, err := range scoro.Watch(ctx, client.Invoices(), nil, time.Minute) {
+//			if err != nil {
+//				log.Print(err)
+//				continue
+//			}
+//			fmt.Println(event.Type, *event.Record.Id)
+//		}
+//
+// Fields modified_date, deleted_date and is_deleted of filter are replaced.
+func Watch[T any, PT WatchEntity[T]](ctx context.Context, service EntityService[T], filter Filter, interval time.Duration) iter.Seq2[Event[T], error] {
+	return func(yield func(Event[T], error) bool) {
+		w := &watcher[T, PT]{
+			service: service,
+			filter:  filter,
+		}
+
+		for err := w.start(ctx); err != nil; err = w.start(ctx) {
+			if ctx.Err() != nil || !yield(Event[T]{}, err) || sleep(ctx, interval) != nil {
+				return
+			}
+		}
+
+		for {
+			if sleep(ctx, interval) != nil {
+				return
+			}
+
+			events, err := w.poll(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				if !yield(Event[T]{}, err) {
+					return
+				}
+				continue
+			}
+
+			for _, event := range events {
+				if !yield(event, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Private
+
+// watchMark is lower bound of the next poll: the latest reported time and
+// IDs of records reported with exactly that time, which are returned by the
+// next poll again. IDs of older records are dropped, so memory doesn't grow
+// with number of watched records.
+type watchMark struct {
+	since time.Time
+	ids   map[int]bool
+}
+
+// watcher keeps state of Watch between polls.
+type watcher[T any, PT WatchEntity[T]] struct {
+	service EntityService[T]
+	filter  Filter
+
+	maxID    int
+	modified watchMark
+	deleted  watchMark
+}
+
+// start scans all records matching filter and advances marks to the latest
+// times of existing and deleted records. Records are counted as they are
+// loaded, so memory doesn't grow with number of records.
+func (t *watcher[T, PT]) start(ctx context.Context) error {
+	values := t.filterValues()
+	values["is_deleted"] = []Bool{{Value: false}, {Value: true}}
+
+	for record, err := range NewPager(t.service.List, RawFilter(values)).All(ctx) {
+		if err != nil {
+			return err
+		}
+
+		id := PT(&record).GetID()
+		t.see(id)
+
+		if deleted := PT(&record).GetDeletedDate(); !deleted.IsZero() {
+			t.deleted.advance(deleted.Time, id)
+		} else {
+			t.modified.advance(PT(&record).GetModifiedDate().Time, id)
+		}
+	}
+
+	return nil
+}
+
+// poll loads records modified or deleted since the previous poll and returns
+// events of changes which haven't been reported yet, ordered by time.
+func (t *watcher[T, PT]) poll(ctx context.Context) ([]Event[T], error) {
+	modified, err := NewPager(t.service.List, t.changedSince("modified_date", t.modified.since)).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := NewPager(t.service.List, t.changedSince("deleted_date", t.deleted.since)).Collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	modified = unseen(&t.modified, modified, PT.GetModifiedDate)
+	deleted = unseen(&t.deleted, deleted, PT.GetDeletedDate)
+
+	type change struct {
+		at    time.Time
+		event Event[T]
+	}
+
+	changes := make([]change, 0, len(modified)+len(deleted))
+	for _, record := range modified {
+		eventType := EventUpdated
+		if PT(&record).GetID() > t.maxID {
+			eventType = EventCreated
+		}
+
+		t.see(PT(&record).GetID())
+		changes = append(changes, change{at: PT(&record).GetModifiedDate().Time, event: Event[T]{Type: eventType, Record: record}})
+	}
+
+	for _, record := range deleted {
+		t.see(PT(&record).GetID())
+		changes = append(changes, change{at: PT(&record).GetDeletedDate().Time, event: Event[T]{Type: EventDeleted, Record: record}})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].at.Before(changes[j].at)
+	})
+
+	events := make([]Event[T], len(changes))
+	for i, change := range changes {
+		events[i] = change.event
+	}
+
+	return events, nil
+}
+
+// changedSince returns filter of existing records modified since specified
+// time when field is modified_date and of deleted records otherwise.
+func (t *watcher[T, PT]) changedSince(field string, since time.Time) Filter {
+	values := t.filterValues()
+	if field == "deleted_date" {
+		values["is_deleted"] = Bool{Value: true}
+	}
+	values.setTimeRange(field, TimeRange{From: since})
+
+	return RawFilter(values)
+}
+
+// filterValues returns copy of filter values without fields set by the
+// watcher.
+func (t *watcher[T, PT]) filterValues() filterValues {
+	values := make(filterValues)
+	if t.filter != nil {
+		for key, value := range t.filter.FilterValues() {
+			values[key] = value
+		}
+	}
+
+	delete(values, "modified_date")
+	delete(values, "deleted_date")
+	delete(values, "is_deleted")
+
+	return values
+}
+
+func (t *watcher[T, PT]) see(id int) {
+	if id > t.maxID {
+		t.maxID = id
+	}
+}
+
+// unseen drops records reported before mark and sorts the rest by time and
+// ID, then advances mark to the latest of them.
+func unseen[T any, PT Entity[T]](mark *watchMark, records []T, at func(PT) Time) []T {
+	result := records[:0]
+	for _, record := range records {
+		recordTime := at(PT(&record)).Time
+		if recordTime.Before(mark.since) || recordTime.Equal(mark.since) && mark.ids[PT(&record).GetID()] {
+			continue
+		}
+
+		result = append(result, record)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := at(PT(&result[i])).Time, at(PT(&result[j])).Time
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+
+		return PT(&result[i]).GetID() < PT(&result[j]).GetID()
+	})
+
+	for _, record := range result {
+		mark.advance(at(PT(&record)).Time, PT(&record).GetID())
+	}
+
+	return result
+}
+
+// advance moves mark to time of record if it's later and remembers ID of
+// record with time of mark.
+func (t *watchMark) advance(at time.Time, id int) {
+	if at.After(t.since) {
+		t.since = at
+		t.ids = nil
+	}
+
+	if at.Equal(t.since) {
+		if t.ids == nil {
+			t.ids = make(map[int]bool)
+		}
+		t.ids[id] = true
+	}
+}
@@ -0,0 +1,182 @@
+package scoro_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/scorotest"
+)
+
+func TestWatch(t *testing.T) {
+	services := []struct {
+		name string
+		new  func(t *testing.T, now func() time.Time) scoro.InvoicesService
+	}{
+		{
+			name: "memory",
+			new: func(t *testing.T, now func() time.Time) scoro.InvoicesService {
+				service := scorotest.NewMemoryService[scoro.Invoice]("invoices")
+				service.SetNow(now)
+				return service
+			},
+		},
+		{
+			name: "server",
+			new: func(t *testing.T, now func() time.Time) scoro.InvoicesService {
+				srv := newTestServer(t)
+				srv.SetNow(now)
+				return srv.Client().Invoices()
+			},
+		},
+	}
+
+	type step struct {
+		seconds int
+		change  func(ctx context.Context, invoices scoro.InvoicesService) error
+		event   string
+	}
+
+	modify := func(id int, status string) func(ctx context.Context, invoices scoro.InvoicesService) error {
+		return func(ctx context.Context, invoices scoro.InvoicesService) error {
+			invoice := scoro.Invoice{Status: status}
+			if id > 0 {
+				invoice.Id = &id
+			}
+
+			_, err := invoices.Modify(ctx, invoice)
+			return err
+		}
+	}
+
+	remove := func(id int) func(ctx context.Context, invoices scoro.InvoicesService) error {
+		return func(ctx context.Context, invoices scoro.InvoicesService) error {
+			return invoices.Delete(ctx, id)
+		}
+	}
+
+	scenarios := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "update first",
+			steps: []step{
+				{seconds: 1, change: modify(1, "sent"), event: "updated 1"},
+				{seconds: 2, change: modify(0, "new"), event: "created 3"},
+				{seconds: 3, change: modify(3, "sent"), event: "updated 3"},
+				{seconds: 3, change: remove(2), event: "deleted 2"},
+				{seconds: 4, change: modify(1, "paid"), event: "updated 1"},
+			},
+		},
+		{
+			name: "create first",
+			steps: []step{
+				{seconds: 1, change: modify(0, "new"), event: "created 3"},
+				{seconds: 2, change: modify(0, "new"), event: "created 4"},
+				{seconds: 2, change: modify(2, "sent"), event: "updated 2"},
+			},
+		},
+		{
+			name: "delete first",
+			steps: []step{
+				{seconds: 1, change: remove(1), event: "deleted 1"},
+				{seconds: 1, change: modify(0, "new"), event: "created 3"},
+			},
+		},
+	}
+
+	for _, service := range services {
+		for _, scenario := range scenarios {
+			t.Run(service.name+"/"+scenario.name, func(t *testing.T) {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+
+				// Services stamp records in a time zone other than the local
+				// one, Watch starts at the latest time stored by them.
+				base := time.Now().Add(-7 * time.Hour).Truncate(time.Second)
+
+				var mu sync.Mutex
+				now := base.Add(-time.Hour)
+				invoices := service.new(t, func() time.Time {
+					mu.Lock()
+					defer mu.Unlock()
+					return now
+				})
+
+				// Records existing before watching started aren't reported.
+				for _, status := range []string{"draft", "draft"} {
+					if err := modify(0, status)(ctx, invoices); err != nil {
+						t.Fatal(err)
+					}
+				}
+
+				// Changes are made after the initial scan of Watch.
+				scanned := make(chan struct{}, 1)
+				events := make(chan scoro.Event[scoro.Invoice])
+				go func() {
+					watched := listNotifier{InvoicesService: invoices, lists: scanned}
+					for event, err := range scoro.Watch(ctx, watched, nil, 5*time.Millisecond) {
+						if err != nil {
+							t.Error(err)
+							return
+						}
+
+						select {
+						case events <- event:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}()
+
+				select {
+				case <-scanned:
+				case <-ctx.Done():
+					t.Fatal("watch didn't scan records")
+				}
+
+				for i, step := range scenario.steps {
+					mu.Lock()
+					now = base.Add(time.Duration(step.seconds) * time.Second)
+					mu.Unlock()
+
+					if err := step.change(ctx, invoices); err != nil {
+						t.Fatal(err)
+					}
+
+					var event scoro.Event[scoro.Invoice]
+					select {
+					case event = <-events:
+					case <-ctx.Done():
+						t.Fatalf("step %d: watch stopped", i)
+					}
+
+					if got := fmt.Sprint(event.Type, " ", *event.Record.Id); got != step.event {
+						t.Fatalf("step %d: got event %q, want %q", i, got, step.event)
+					}
+				}
+			})
+		}
+	}
+}
+
+// listNotifier notifies about completed list requests.
+type listNotifier struct {
+	scoro.InvoicesService
+	lists chan struct{}
+}
+
+func (t listNotifier) List(ctx context.Context, filter scoro.Filter, page int, count int) ([]scoro.Invoice, error) {
+	records, err := t.InvoicesService.List(ctx, filter, page, count)
+
+	select {
+	case t.lists <- struct{}{}:
+	default:
+	}
+
+	return records, err
+}
@@ -0,0 +1,304 @@
+// Package webhook provides http.Handler receiving Scoro webhook
+// notifications and dispatching them to typed callbacks.
+//
+// Example:
+//
+//		handler := webhook.NewHandler().SetSecret(os.Getenv("SCORO_WEBHOOK_SECRET"))
+//		webhook.OnInvoice(handler, func(ctx context.Context, event webhook.Event[scoro.Invoice]) error {
+//			return syncInvoice(ctx, event.Action, event.Record)
+//		})
+//		http.Handle("/scoro/webhook", handler)
+//
+// Notification is JSON object with module, action, object id and record
+// data:
+//
+//		{"module": "invoices", "action": "modify", "object_id": 1, "data": {...}}
+//
+// Handler responds with 204 No Content when notification is handled or when
+// there is no callback of its module, 401 when secret or signature doesn't
+// match, 400 or 422 when notification can't be decoded and 500 when callback
+// fails or neither secret nor signing key is set, so Scoro can deliver it
+// again.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	scoro "github.com/lxmx/go-scoro"
+)
+
+const (
+	// SecretHeader is header carrying shared secret.
+	SecretHeader = "X-Scoro-Secret"
+
+	// SecretParam is query parameter carrying shared secret, it can be used
+	// when secret is put into webhook URL.
+	SecretParam = "secret"
+
+	// SignatureHeader is header carrying HMAC-SHA256 signature of request
+	// body, hex encoded and optionally prefixed by "sha256=".
+	SignatureHeader = "X-Scoro-Signature"
+
+	// MaxBodySize is maximum size of accepted notification.
+	MaxBodySize = 1 << 20
+)
+
+var (
+	// ErrUnauthorized is reported when secret or signature doesn't match.
+	ErrUnauthorized = errors.New("webhook: invalid secret or signature")
+
+	// ErrInvalidPayload is reported when notification can't be decoded.
+	ErrInvalidPayload = errors.New("webhook: invalid payload")
+
+	// ErrNoModule is reported when notification has no module.
+	ErrNoModule = errors.New("webhook: payload has no module")
+
+	// ErrNotConfigured is reported when handler has neither secret nor
+	// signing key, such handler rejects all notifications.
+	ErrNotConfigured = errors.New("webhook: neither secret nor signing key is set")
+)
+
+// Payload is notification sent by Scoro.
+type Payload struct {
+	Module    string          `json:"module"`
+	Action    string          `json:"action"`
+	ObjectID  int             `json:"object_id"`
+	CompanyID string          `json:"company_account_id,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// Event is notification with record decoded into data type of its module.
+// Record has only ID set for notifications without data, e.g. of deleted
+// records.
+type Event[T any] struct {
+	Module  string
+	Action  string
+	ID      int
+	Record  T
+	Payload Payload
+}
+
+// Handler is http.Handler receiving Scoro notifications.
+type Handler struct {
+	secret     string
+	signingKey []byte
+	onError    func(r *http.Request, err error)
+
+	mu        sync.RWMutex
+	callbacks map[string]func(ctx context.Context, payload Payload) error
+}
+
+// NewHandler creates handler without callbacks. Secret or signing key must be
+// set before handler accepts notifications.
+func NewHandler() *Handler {
+	return &Handler{callbacks: make(map[string]func(ctx context.Context, payload Payload) error)}
+}
+
+// SetSecret sets shared secret, which must be sent in SecretHeader header or
+// SecretParam query parameter.
+func (t *Handler) SetSecret(secret string) *Handler {
+	t.secret = secret
+	return t
+}
+
+// SetSigningKey sets key of HMAC-SHA256 signature of request body, which
+// must be sent in SignatureHeader header.
+func (t *Handler) SetSigningKey(key []byte) *Handler {
+	t.signingKey = key
+	return t
+}
+
+// OnError sets function receiving errors of rejected notifications and
+// failed callbacks, e.g. to log them.
+func (t *Handler) OnError(onError func(r *http.Request, err error)) *Handler {
+	t.onError = onError
+	return t
+}
+
+// ServeHTTP implements http.Handler.
+func (t *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		t.fail(w, r, http.StatusMethodNotAllowed, fmt.Errorf("webhook: method %v isn't allowed", r.Method))
+		return
+	}
+
+	if t.secret == "" && len(t.signingKey) == 0 {
+		t.fail(w, r, http.StatusInternalServerError, ErrNotConfigured)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			t.fail(w, r, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+
+		t.fail(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if !t.verify(r, body) {
+		t.fail(w, r, http.StatusUnauthorized, ErrUnauthorized)
+		return
+	}
+
+	var payload Payload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.fail(w, r, http.StatusBadRequest, fmt.Errorf("%w: %v", ErrInvalidPayload, err))
+		return
+	}
+
+	if payload.Module == "" {
+		t.fail(w, r, http.StatusBadRequest, ErrNoModule)
+		return
+	}
+
+	t.mu.RLock()
+	callback, ok := t.callbacks[payload.Module]
+	t.mu.RUnlock()
+
+	if ok {
+		if err := callback(r.Context(), payload); err != nil {
+			status := http.StatusInternalServerError
+			if errors.Is(err, ErrInvalidPayload) {
+				status = http.StatusUnprocessableEntity
+			}
+
+			t.fail(w, r, status, err)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Handle registers callback of notifications of specified module, records
+// are decoded into T. Callback replaces previously registered callback of
+// the module.
+func Handle[T any, PT scoro.Entity[T]](handler *Handler, module string, callback func(ctx context.Context, event Event[T]) error) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+
+	handler.callbacks[module] = func(ctx context.Context, payload Payload) error {
+		event := Event[T]{
+			Module:  payload.Module,
+			Action:  payload.Action,
+			Payload: payload,
+		}
+
+		if len(payload.Data) > 0 && string(payload.Data) != "null" {
+			if err := json.Unmarshal(payload.Data, &event.Record); err != nil {
+				return fmt.Errorf("%w: %v data: %v", ErrInvalidPayload, payload.Module, err)
+			}
+		}
+
+		record := PT(&event.Record)
+		if record.GetID() == 0 && payload.ObjectID != 0 {
+			record.SetID(payload.ObjectID)
+		}
+		event.ID = record.GetID()
+
+		return callback(ctx, event)
+	}
+}
+
+// OnProduct registers callback of products notifications.
+func OnProduct(handler *Handler, callback func(ctx context.Context, event Event[scoro.Product]) error) {
+	Handle(handler, "products", callback)
+}
+
+// OnQuote registers callback of quotes notifications.
+func OnQuote(handler *Handler, callback func(ctx context.Context, event Event[scoro.Quote]) error) {
+	Handle(handler, "quotes", callback)
+}
+
+// OnOrder registers callback of orders notifications.
+func OnOrder(handler *Handler, callback func(ctx context.Context, event Event[scoro.Order]) error) {
+	Handle(handler, "orders", callback)
+}
+
+// OnInvoice registers callback of invoices notifications.
+func OnInvoice(handler *Handler, callback func(ctx context.Context, event Event[scoro.Invoice]) error) {
+	Handle(handler, "invoices", callback)
+}
+
+// OnPrepayment registers callback of prepayments notifications.
+func OnPrepayment(handler *Handler, callback func(ctx context.Context, event Event[scoro.Invoice]) error) {
+	Handle(handler, "invoices/prepayments", callback)
+}
+
+// OnContact registers callback of contacts notifications.
+func OnContact(handler *Handler, callback func(ctx context.Context, event Event[scoro.Contact]) error) {
+	Handle(handler, "contacts", callback)
+}
+
+// OnReceipt registers callback of receipts notifications.
+func OnReceipt(handler *Handler, callback func(ctx context.Context, event Event[scoro.Receipt]) error) {
+	Handle(handler, "receipts", callback)
+}
+
+// OnRelation registers callback of relations notifications.
+func OnRelation(handler *Handler, callback func(ctx context.Context, event Event[scoro.Relation]) error) {
+	Handle(handler, "relations", callback)
+}
+
+// Sign returns signature of body, which is expected in SignatureHeader. It
+// is useful to sign notifications in tests.
+func Sign(key []byte, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Private
+
+func (t *Handler) verify(r *http.Request, body []byte) bool {
+	if t.secret != "" {
+		secret := r.Header.Get(SecretHeader)
+		if secret == "" {
+			secret = r.URL.Query().Get(SecretParam)
+		}
+
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(t.secret)) != 1 {
+			return false
+		}
+	}
+
+	if len(t.signingKey) > 0 {
+		signature := strings.TrimPrefix(r.Header.Get(SignatureHeader), "sha256=")
+		decoded, err := hex.DecodeString(signature)
+		if err != nil {
+			return false
+		}
+
+		expected, _ := hex.DecodeString(Sign(t.signingKey, body))
+		if !hmac.Equal(decoded, expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *Handler) fail(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if t.onError != nil {
+		t.onError(r, err)
+	}
+
+	http.Error(w, http.StatusText(status), status)
+}
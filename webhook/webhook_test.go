@@ -0,0 +1,222 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	scoro "github.com/lxmx/go-scoro"
+	"github.com/lxmx/go-scoro/webhook"
+)
+
+func TestHandler(t *testing.T) {
+	secret := "secret"
+	key := []byte("key")
+
+	sign := func(body string) string {
+		return "sha256=" + webhook.Sign(key, []byte(body))
+	}
+
+	invoice := `{"module":"invoices","action":"modify","object_id":7,"data":{"no":"A1","status":"paid"}}`
+	deleted := `{"module":"invoices","action":"delete","object_id":8}`
+	prepayment := `{"module":"invoices/prepayments","action":"modify","object_id":9,"data":{"no":"P1"}}`
+	invalidContact := `{"module":"contacts","action":"modify","data":{"contact_id":"x"}}`
+	failing := `{"module":"invoices","action":"fail","object_id":1}`
+	unknown := `{"module":"tasks","action":"modify","object_id":1}`
+	noModule := `{"action":"modify","object_id":1}`
+	errCallback := errors.New("callback failed")
+
+	tests := []struct {
+		name      string
+		handler   func() *webhook.Handler
+		method    string
+		body      string
+		secret    string
+		signature string
+		status    int
+		event     string
+		err       error
+	}{
+		{
+			name:      "invoice is handled",
+			body:      invoice,
+			secret:    secret,
+			signature: sign(invoice),
+			status:    http.StatusNoContent,
+			event:     "invoices modify 7 A1",
+		},
+		{
+			name:      "signature without prefix",
+			body:      invoice,
+			secret:    secret,
+			signature: webhook.Sign(key, []byte(invoice)),
+			status:    http.StatusNoContent,
+			event:     "invoices modify 7 A1",
+		},
+		{
+			name:      "record without data gets object ID",
+			body:      deleted,
+			secret:    secret,
+			signature: sign(deleted),
+			status:    http.StatusNoContent,
+			event:     "invoices delete 8 ",
+		},
+		{
+			name:      "prepayment is handled",
+			body:      prepayment,
+			secret:    secret,
+			signature: sign(prepayment),
+			status:    http.StatusNoContent,
+			event:     "invoices/prepayments modify 9 P1",
+		},
+		{
+			name:      "wrong secret",
+			body:      invoice,
+			secret:    "wrong",
+			signature: sign(invoice),
+			status:    http.StatusUnauthorized,
+			err:       webhook.ErrUnauthorized,
+		},
+		{
+			name:      "wrong signature",
+			body:      invoice,
+			secret:    secret,
+			signature: sign(deleted),
+			status:    http.StatusUnauthorized,
+			err:       webhook.ErrUnauthorized,
+		},
+		{
+			name:    "secret only",
+			handler: func() *webhook.Handler { return webhook.NewHandler().SetSecret(secret) },
+			body:    unknown,
+			secret:  secret,
+			status:  http.StatusNoContent,
+		},
+		{
+			name:    "handler without secret and key",
+			handler: webhook.NewHandler,
+			body:    unknown,
+			status:  http.StatusInternalServerError,
+			err:     webhook.ErrNotConfigured,
+		},
+		{
+			name:   "wrong method",
+			method: http.MethodGet,
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:      "invalid JSON",
+			body:      "{",
+			secret:    secret,
+			signature: sign("{"),
+			status:    http.StatusBadRequest,
+			err:       webhook.ErrInvalidPayload,
+		},
+		{
+			name:      "payload without module",
+			body:      noModule,
+			secret:    secret,
+			signature: sign(noModule),
+			status:    http.StatusBadRequest,
+			err:       webhook.ErrNoModule,
+		},
+		{
+			name:      "invalid record",
+			body:      invalidContact,
+			secret:    secret,
+			signature: sign(invalidContact),
+			status:    http.StatusUnprocessableEntity,
+			err:       webhook.ErrInvalidPayload,
+		},
+		{
+			name:      "failed callback",
+			body:      failing,
+			secret:    secret,
+			signature: sign(failing),
+			status:    http.StatusInternalServerError,
+			err:       errCallback,
+		},
+		{
+			name:      "module without callback",
+			body:      unknown,
+			secret:    secret,
+			signature: sign(unknown),
+			status:    http.StatusNoContent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := webhook.NewHandler().SetSecret(secret).SetSigningKey(key)
+			if test.handler != nil {
+				handler = test.handler()
+			}
+
+			var err error
+			handler.OnError(func(r *http.Request, handlerErr error) {
+				err = handlerErr
+			})
+
+			var event string
+			record := func(module, action string, id int, no string) {
+				event = fmt.Sprintf("%v %v %v %v", module, action, id, no)
+			}
+
+			webhook.OnInvoice(handler, func(ctx context.Context, e webhook.Event[scoro.Invoice]) error {
+				if e.Action == "fail" {
+					return errCallback
+				}
+				record(e.Module, e.Action, e.ID, e.Record.No)
+				return nil
+			})
+			webhook.OnPrepayment(handler, func(ctx context.Context, e webhook.Event[scoro.Invoice]) error {
+				record(e.Module, e.Action, e.ID, e.Record.No)
+				return nil
+			})
+			webhook.OnContact(handler, func(ctx context.Context, e webhook.Event[scoro.Contact]) error {
+				return nil
+			})
+
+			method := test.method
+			if method == "" {
+				method = http.MethodPost
+			}
+
+			r := httptest.NewRequest(method, "/webhook", strings.NewReader(test.body))
+			r.Header.Set(webhook.SecretHeader, test.secret)
+			r.Header.Set(webhook.SignatureHeader, test.signature)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != test.status {
+				t.Errorf("got status %d, want %d", w.Code, test.status)
+			}
+
+			if event != test.event {
+				t.Errorf("got event %q, want %q", event, test.event)
+			}
+
+			if test.err != nil && !errors.Is(err, test.err) {
+				t.Errorf("got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestHandlerSecretParam(t *testing.T) {
+	handler := webhook.NewHandler().SetSecret("secret")
+
+	for secret, status := range map[string]int{"secret": http.StatusNoContent, "wrong": http.StatusUnauthorized} {
+		r := httptest.NewRequest(http.MethodPost, "/webhook?"+webhook.SecretParam+"="+secret, strings.NewReader(`{"module":"tasks"}`))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != status {
+			t.Errorf("secret %q: got status %d, want %d", secret, w.Code, status)
+		}
+	}
+}